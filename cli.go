@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 命令行子命令的退出码
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// subcommands 无界面运行的子命令，第一个参数匹配时不启动 GUI
+var subcommands = map[string]func(args []string) int{
+	"render": runRender,
+	"info":   runInfo,
+	"text":   runText,
+	"grep":   runGrep,
+}
+
+// runSubcommand 执行子命令，args[0] 不是子命令时返回 false
+func runSubcommand(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return 0, false
+	}
+	return cmd(args[1:]), true
+}
+
+// parseInterspersed 解析参数，允许选项出现在位置参数之后（例如 render a.pdf --dpi 200）
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// runRender 将页面渲染为图片文件：pdfviewer render <file> --pages 1-5 --dpi 200 --format png -o out/
+func runRender(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	pageSpec := fs.String("pages", "", "页码范围，例如 1-3,7,10-（默认全部页面）")
+	dpi := fs.Int("dpi", defaultExportDPI, "渲染分辨率（SVG 忽略）")
+	formatName := fs.String("format", string(ImagePNG), "输出格式：png、jpeg 或 svg")
+	quality := fs.Int("quality", defaultJPEGQuality, "JPEG 质量（1-100）")
+	outDir := fs.String("o", ".", "输出目录")
+	concurrency := fs.Int("concurrency", runtime.NumCPU(), "并发渲染的协程数，每个协程使用独立的 MuPDF 文档句柄")
+	password := fs.String("password", "", "打开加密 PDF 时使用的密码")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s render <file.pdf | URI | -> [options]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	format, err := parseImageFormat(*formatName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return exitUsage
+	}
+	if format != ImageSVG && (*dpi < minExportDPI || *dpi > maxExportDPI) {
+		fmt.Fprintf(os.Stderr, "render: DPI 必须在 %d 到 %d 之间\n", minExportDPI, maxExportDPI)
+		return exitUsage
+	}
+	opts := ExportOptions{Format: format, DPI: *dpi, Quality: *quality}
+
+	if err := renderDocument(positional[0], *password, *pageSpec, *outDir, *concurrency, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// renderDocument 按页码范围并发渲染文档，每页写入一个文件
+func renderDocument(arg, password, pageSpec, outDir string, concurrency int, opts ExportOptions) error {
+	// 一次性渲染不会重复访问页面，关闭页面缓存以节省内存
+	sharedPageCache.SetBudget(0)
+
+	src, err := argSource(arg)
+	if err != nil {
+		return err
+	}
+	first, err := src.openEngine(password)
+	if err != nil {
+		return err
+	}
+	defer first.Close()
+
+	pages, err := parsePageRange(pageSpec, first.GetPageCount())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(pages) {
+		concurrency = len(pages)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for _, page := range pages {
+			select {
+			case jobs <- page:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// MuPDF 文档句柄不能并发使用，每个协程打开自己的句柄
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			engine := first
+			if worker > 0 {
+				e, err := src.openEngine(password)
+				if err != nil {
+					fail(err)
+					return
+				}
+				defer e.Close()
+				engine = e
+			}
+
+			for page := range jobs {
+				if err := renderPageFile(engine, page, outDir, opts); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	fmt.Fprintf(os.Stderr, "已渲染 %d 页到 %s\n", len(pages), outDir)
+	return nil
+}
+
+// renderPageFile 渲染单页并写入输出目录
+func renderPageFile(engine *PDFEngine, page int, outDir string, opts ExportOptions) error {
+	name := exportFileName(engine.GetFileName(), page, engine.GetPageCount(), opts.Format)
+	path := filepath.Join(outDir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = engine.ExportPage(f, page, opts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("导出第 %d 页失败: %w", page, err)
+	}
+	return nil
+}
+
+// runInfo 输出文档属性：pdfviewer info <file>... [--format json|text]
+// 任一文件无法读取时退出码非零，其余文件照常输出
+func runInfo(args []string) int {
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	format := fs.String("format", "json", "输出格式：json 或 text")
+	password := fs.String("password", "", "打开加密 PDF 时使用的密码")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s info <file>... [options]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	files, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(files) == 0 || (*format != "json" && *format != "text") {
+		fs.Usage()
+		return exitUsage
+	}
+
+	code := exitOK
+	infos := make([]*DocumentInfo, 0, len(files))
+	for _, arg := range files {
+		info, err := readDocumentInfo(arg, *password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "info: %s: %v\n", arg, err)
+			code = exitError
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	if *format == "text" {
+		writeInfoText(os.Stdout, infos, GetTranslations(LangEnglish))
+	} else {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(infos); err != nil {
+			fmt.Fprintf(os.Stderr, "info: %v\n", err)
+			return exitError
+		}
+	}
+	return code
+}
+
+// readDocumentInfo 打开文档并读取属性
+func readDocumentInfo(arg, password string) (*DocumentInfo, error) {
+	src, err := argSource(arg)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := src.openEngine(password)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+
+	return engine.GetInfo()
+}
+
+// writeInfoText 以对齐的表格形式输出属性，多个文件之间空一行
+func writeInfoText(w io.Writer, infos []*DocumentInfo, tr *Translations) {
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fields := info.Fields(tr)
+		width := 0
+		for _, field := range fields {
+			if n := utf8.RuneCountInString(field.Label); n > width {
+				width = n
+			}
+		}
+
+		for _, field := range fields {
+			pad := strings.Repeat(" ", width-utf8.RuneCountInString(field.Label))
+			indent := "\n" + strings.Repeat(" ", width+3)
+			fmt.Fprintf(w, "%s:%s  %s\n", field.Label, pad, strings.ReplaceAll(field.Value, "\n", indent))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureStdout 执行 fn 并返回其写入标准输出的内容
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stdout := os.Stdout
+	os.Stdout = f
+	defer func() { os.Stdout = stdout }()
+	fn()
+
+	out, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestRunInfoOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	data := buildTestPDF("/Title (Annual Report) /Producer (Writer) /ModDate (D:20240607080910Z)")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"json", "text"} {
+		var code int
+		out := captureStdout(t, func() {
+			code = runInfo([]string{"--format", format, path})
+		})
+		if code != exitOK {
+			t.Fatalf("info --format %s exit code = %d, want %d", format, code, exitOK)
+		}
+		if strings.ContainsRune(out, 0) || strings.Contains(out, `\u0000`) {
+			t.Errorf("info --format %s output contains NUL:\n%q", format, out)
+		}
+		if !strings.Contains(out, "Annual Report") || !strings.Contains(out, "2024-06-07") {
+			t.Errorf("info --format %s output lacks title or modification date:\n%s", format, out)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// grep 子命令遵循 grep 的退出码约定：有匹配为 0，无匹配为 1，出错为 2
+const (
+	exitNoMatch = 1
+	exitTrouble = 2
+)
+
+// runText 输出文档文本：pdfviewer text <file> [--pages 1-5] [--format txt|html|md]
+func runText(args []string) int {
+	flags := flag.NewFlagSet("text", flag.ContinueOnError)
+	pageSpec := flags.String("pages", "", "页码范围，例如 1-3,7,10-（默认全部页面）")
+	formatName := flags.String("format", string(TextPlain), "输出格式：txt、html 或 md")
+	password := flags.String("password", "", "打开加密 PDF 时使用的密码")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s text <file.pdf | URI | -> [options]\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	format, err := parseTextFormat(*formatName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "text: %v\n", err)
+		return exitUsage
+	}
+
+	if err := writeDocumentText(os.Stdout, positional[0], *password, *pageSpec, format); err != nil {
+		fmt.Fprintf(os.Stderr, "text: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// writeDocumentText 打开文档并按格式输出页码范围内的文本
+func writeDocumentText(w io.Writer, arg, password, pageSpec string, format TextFormat) error {
+	src, err := argSource(arg)
+	if err != nil {
+		return err
+	}
+	engine, err := src.openEngine(password)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	pages, err := parsePageRange(pageSpec, engine.GetPageCount())
+	if err != nil {
+		return err
+	}
+	return engine.ExportText(context.Background(), w, pages, format)
+}
+
+// grepOptions grep 子命令的输出选项
+type grepOptions struct {
+	before, after int // 匹配行之前/之后输出的上下文行数
+}
+
+// runGrep 在文档中搜索：pdfviewer grep <pattern> <files...>
+// 目录会被递归搜索，只包含支持的文档格式；输出格式为 file:page: line
+func runGrep(args []string) int {
+	flags := flag.NewFlagSet("grep", flag.ContinueOnError)
+	ignoreCase := flags.Bool("i", false, "忽略大小写")
+	fixed := flags.Bool("F", false, "按普通字符串匹配，不使用正则表达式")
+	wholeWord := flags.Bool("w", false, "全字匹配")
+	contextLines := flags.Int("C", 0, "匹配行前后各输出的上下文行数")
+	before := flags.Int("B", 0, "匹配行之前输出的上下文行数")
+	after := flags.Int("A", 0, "匹配行之后输出的上下文行数")
+	password := flags.String("password", "", "打开加密 PDF 时使用的密码")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s grep <pattern> <file | dir>... [options]\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitTrouble
+	}
+	if len(positional) < 2 {
+		flags.Usage()
+		return exitTrouble
+	}
+
+	pattern, err := compileSearch(positional[0], SearchOptions{
+		CaseSensitive: !*ignoreCase,
+		WholeWord:     *wholeWord,
+		Regex:         !*fixed,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "grep: 无效的正则表达式: %v\n", err)
+		return exitTrouble
+	}
+
+	opts := grepOptions{before: *before, after: *after}
+	if *contextLines > opts.before {
+		opts.before = *contextLines
+	}
+	if *contextLines > opts.after {
+		opts.after = *contextLines
+	}
+
+	files, walkErr := collectDocuments(positional[1:])
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	matched, failed := false, walkErr != nil
+	for _, file := range files {
+		found, err := grepDocument(out, file, *password, pattern, opts)
+		if err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "grep: %s: %v\n", file, err)
+			failed = true
+			continue
+		}
+		matched = matched || found
+	}
+
+	switch {
+	case failed:
+		return exitTrouble
+	case !matched:
+		return exitNoMatch
+	}
+	return exitOK
+}
+
+// collectDocuments 展开参数中的目录，返回其中所有支持格式的文件
+func collectDocuments(args []string) ([]string, error) {
+	var files []string
+	var firstErr error
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil || !info.IsDir() {
+			// 无法访问的路径交给 grepDocument 报告错误
+			files = append(files, arg)
+			continue
+		}
+
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "grep: %s: %v\n", path, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				return nil
+			}
+			if !d.IsDir() {
+				if _, ok := formatExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+					files = append(files, path)
+				}
+			}
+			return nil
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return files, firstErr
+}
+
+// grepDocument 逐页搜索文档，输出匹配行及上下文，返回是否有匹配
+// 匹配行以 file:page: 开头，上下文行以 file:page- 开头，不相邻的输出组之间用 -- 分隔
+func grepDocument(w io.Writer, arg, password string, pattern *searchPattern, opts grepOptions) (bool, error) {
+	src, err := argSource(arg)
+	if err != nil {
+		return false, err
+	}
+	engine, err := src.openEngine(password)
+	if err != nil {
+		return false, err
+	}
+	defer engine.Close()
+
+	matched := false
+	for page := 1; page <= engine.GetPageCount(); page++ {
+		text, err := engine.GetPageText(page)
+		if err != nil {
+			return matched, err
+		}
+
+		lines := strings.Split(text, "\n")
+		lastPrinted := -1
+		for i, line := range lines {
+			if !pattern.MatchString(line) {
+				continue
+			}
+
+			start := i - opts.before
+			if start <= lastPrinted {
+				start = lastPrinted + 1
+			}
+			if matched && (lastPrinted < 0 || start > lastPrinted+1) && (opts.before > 0 || opts.after > 0) {
+				fmt.Fprintln(w, "--")
+			}
+			for j := start; j < i; j++ {
+				fmt.Fprintf(w, "%s:%d- %s\n", arg, page, lines[j])
+			}
+			fmt.Fprintf(w, "%s:%d: %s\n", arg, page, line)
+			lastPrinted = i
+			matched = true
+
+			// 之后的上下文行中如有匹配，会在下一轮作为匹配行输出
+			for j := i + 1; j <= i+opts.after && j < len(lines) && !pattern.MatchString(lines[j]); j++ {
+				fmt.Fprintf(w, "%s:%d- %s\n", arg, page, lines[j])
+				lastPrinted = j
+			}
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,525 @@
+package main
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// continuousGap 连续模式下页面之间的间距
+const continuousGap = 8
+
+// pageSlot 连续模式中一页的位置、渲染状态和链接
+type pageSlot struct {
+	page      int
+	y         float32 // 页面顶部在内容中的纵坐标
+	size      fyne.Size
+	opts      RenderOptions
+	placement pagePlacement // 页面尺寸和旋转，用于页面坐标与图像坐标的换算
+	view      *pageView
+	links     []PageLink         // 页上的链接（页面坐标），图像显示后读取
+	loaded    bool               // 已渲染或已排队渲染
+	cancel    context.CancelFunc // 取消排队中的渲染
+}
+
+// continuousView 连续滚动视图：所有页面纵向排列，进入视口附近时渲染，远离时释放
+// 页面通过控制器的渲染协程排队渲染；除后台读取页面尺寸外，所有状态只在界面协程中访问
+type continuousView struct {
+	controller    *Controller
+	onPageChanged func(page int)                                                          // 用户滚动导致当前页变化时回调
+	pageRects     func(page int, placement pagePlacement) []overlayRect                   // 页上的高亮（该页图像坐标）
+	onTap         func(page int, x, y float64, link *PageLink, modifier fyne.KeyModifier) // 单击页面（页面坐标）
+	onHover       func(link *PageLink)                                                    // 鼠标下方的链接变化
+
+	scroll  *container.Scroll
+	content *fyne.Container
+	loading *widget.Label
+
+	engine     DocumentEngine // 页面尺寸对应的文档
+	pageCount  int
+	sizes      []PageSize // 各页尺寸（点）
+	slots      []*pageSlot
+	dpi        int
+	rotation   int        // 布局对应的旋转设置版本
+	generation uint64     // 文档、页数、DPI 或旋转变化时递增，使旧的渲染结果作废
+	target     *SearchHit // 布局完成后需要滚动到的位置
+	hoverLink  *PageLink
+	scheduled  bool // 已安排在界面协程中更新可见页面
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	layoutCtx    context.Context
+	layoutCancel context.CancelFunc // 布局变化时取消全部排队的渲染
+}
+
+// newContinuousView 创建连续滚动视图，回调均在界面协程中调用
+func newContinuousView(controller *Controller, loadingText string, onPageChanged func(page int), pageRects func(page int, placement pagePlacement) []overlayRect, onTap func(page int, x, y float64, link *PageLink, modifier fyne.KeyModifier), onHover func(link *PageLink)) *continuousView {
+	ctx, cancel := context.WithCancel(context.Background())
+	v := &continuousView{
+		controller:    controller,
+		onPageChanged: onPageChanged,
+		pageRects:     pageRects,
+		onTap:         onTap,
+		onHover:       onHover,
+		loading:       widget.NewLabel(loadingText),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	v.layoutCtx, v.layoutCancel = context.WithCancel(ctx)
+
+	v.content = container.New(&pagesLayout{view: v})
+	v.scroll = container.NewScroll(v.content)
+	v.scroll.OnScrolled = func(fyne.Position) {
+		v.updateVisible(true)
+	}
+	return v
+}
+
+// Stop 取消排队的渲染并释放页面图像，之后到达的渲染结果被丢弃
+func (v *continuousView) Stop() {
+	v.cancel()
+	v.generation++
+	for _, slot := range v.slots {
+		v.release(slot)
+	}
+}
+
+// Sync 与控制器状态同步：文档、页数、DPI 或旋转变化时重新布局，然后滚动到当前页
+func (v *continuousView) Sync() {
+	if v.ctx.Err() != nil {
+		return
+	}
+
+	c := v.controller
+	page, dpi := c.GetCurrentPage(), c.GetDPI()
+	needReload := v.engine != c.engine || v.pageCount != c.GetPageCount()
+	needRescale := v.dpi != dpi || v.rotation != c.GetRotationVersion()
+	v.rotation = c.GetRotationVersion()
+
+	switch {
+	case needReload:
+		v.reload(page, dpi, v.renderOptions())
+	case needRescale:
+		v.rescale(dpi, v.renderOptions())
+		v.ScrollToPage(page)
+	default:
+		v.ScrollToPage(page)
+	}
+}
+
+// renderOptions 读取各页当前的渲染选项，供后台布局和渲染使用
+func (v *continuousView) renderOptions() []RenderOptions {
+	c := v.controller
+	opts := make([]RenderOptions, c.GetPageCount())
+	for i := range opts {
+		opts[i] = c.GetRenderOptions(i + 1)
+	}
+	return opts
+}
+
+// reload 在后台读取全部页面尺寸，完成后回到界面协程重新布局
+func (v *continuousView) reload(page, dpi int, opts []RenderOptions) {
+	engine := v.controller.engine
+	count := v.controller.GetPageCount()
+
+	v.generation++
+	generation := v.generation
+	v.engine = engine
+	v.pageCount = count
+	v.sizes = nil
+	v.resetLayout()
+
+	v.content.Objects = []fyne.CanvasObject{v.loading}
+	v.content.Refresh()
+
+	// 大文档读取页面尺寸需要一定时间，在后台执行
+	ctx := v.ctx
+	go func() {
+		sizes := make([]PageSize, count)
+		for i := range sizes {
+			if ctx.Err() != nil {
+				return
+			}
+			size, err := engine.GetPageSize(i + 1)
+			if err != nil && i > 0 {
+				size = sizes[i-1] // 读取失败时沿用上一页的尺寸
+			} else if err != nil {
+				size = PageSize{Width: 595, Height: 842}
+			}
+			size.Page = i + 1
+			sizes[i] = size
+		}
+
+		fyne.Do(func() {
+			if generation != v.generation || v.ctx.Err() != nil {
+				return
+			}
+			v.sizes = sizes
+			v.rescale(dpi, opts)
+			v.ScrollToPage(page)
+			if target := v.target; target != nil {
+				v.target = nil
+				v.ScrollToRect(target.Page, target.Rect)
+			}
+		})
+	}()
+}
+
+// resetLayout 取消全部排队的渲染并清空页面
+func (v *continuousView) resetLayout() {
+	v.layoutCancel()
+	v.layoutCtx, v.layoutCancel = context.WithCancel(v.ctx)
+	v.slots = nil
+	v.hoverLink = nil
+}
+
+// rescale 按新的 DPI 和旋转重新计算页面位置，已渲染的图像全部作废
+func (v *continuousView) rescale(dpi int, opts []RenderOptions) {
+	v.generation++
+	v.dpi = dpi
+	v.resetLayout()
+
+	scale := float64(dpi) / 72
+	objects := make([]fyne.CanvasObject, len(v.sizes))
+	v.slots = make([]*pageSlot, len(v.sizes))
+	y := float32(0)
+	for i, s := range v.sizes {
+		var o RenderOptions
+		if i < len(opts) {
+			o = opts[i]
+		}
+		shown := rotatedSize(s, o.Rotation)
+		slot := &pageSlot{
+			page:      s.Page,
+			y:         y,
+			size:      fyne.NewSize(float32(shown.Width*scale), float32(shown.Height*scale)),
+			opts:      o,
+			placement: pagePlacement{Size: s, Rotation: o.Rotation},
+		}
+		slot.view = newPageView(v, slot, dpi)
+
+		v.slots[i] = slot
+		objects[i] = slot.view
+		y += slot.size.Height + continuousGap
+	}
+
+	v.content.Objects = objects
+	v.content.Refresh()
+}
+
+// ScrollToPage 滚动到指定页的顶部
+func (v *continuousView) ScrollToPage(page int) {
+	if page < 1 || page > len(v.slots) {
+		return
+	}
+
+	v.scroll.Offset = fyne.NewPos(v.scroll.Offset.X, v.slots[page-1].y)
+	v.scroll.Refresh()
+	v.updateVisible(false)
+}
+
+// ScrollToRect 滚动使指定页上的矩形（页面坐标）出现在视口左上三分之一处
+// 页面尚未布局完成时记下位置，布局完成后再滚动
+func (v *continuousView) ScrollToRect(page int, rect PageRect) {
+	if page < 1 || page > len(v.slots) {
+		v.target = &SearchHit{Page: page, Rect: rect}
+		return
+	}
+
+	slot := v.slots[page-1]
+	shown := slot.placement.Transform(rect)
+	scale := float32(v.dpi) / 72
+	left := (v.content.Size().Width - slot.size.Width) / 2
+	viewport := v.scroll.Size()
+
+	offset := fyne.NewPos(
+		left+float32(shown.X)*scale-viewport.Width/3,
+		slot.y+float32(shown.Y)*scale-viewport.Height/3,
+	)
+	if offset.X < 0 {
+		offset.X = 0
+	}
+	if offset.Y < 0 {
+		offset.Y = 0
+	}
+	v.scroll.Offset = offset
+	v.scroll.Refresh()
+	v.updateVisible(false)
+}
+
+// RefreshOverlays 重新绘制各页的高亮（搜索结果或正向搜索变化后）
+func (v *continuousView) RefreshOverlays() {
+	for _, slot := range v.slots {
+		if slot.view.image.Image != nil {
+			slot.view.overlay.SetRects(v.pageRects(slot.page, slot.placement))
+		}
+	}
+}
+
+// scheduleVisible 在界面协程中安排一次可见页面的更新，同一轮中的多次布局只更新一次
+func (v *continuousView) scheduleVisible() {
+	if v.scheduled {
+		return
+	}
+	v.scheduled = true
+	fyne.Do(func() {
+		v.scheduled = false
+		v.updateVisible(false)
+	})
+}
+
+// updateVisible 为视口附近的页面排队渲染，释放远离视口的页面
+// track 为 true 时（用户滚动）根据滚动位置更新当前页
+func (v *continuousView) updateVisible(track bool) {
+	if v.ctx.Err() != nil {
+		return
+	}
+
+	offset := v.scroll.Offset.Y
+	height := v.scroll.Size().Height
+
+	// 视口上下各预渲染一屏，超过三屏的页面释放图像
+	renderTop, renderBottom := offset-height, offset+2*height
+	keepTop, keepBottom := offset-3*height, offset+4*height
+	probe := offset + height/3
+
+	current := 0
+	var pending []*pageSlot
+	for _, slot := range v.slots {
+		top, bottom := slot.y, slot.y+slot.size.Height
+		if current == 0 && probe < bottom+continuousGap {
+			current = slot.page
+		}
+
+		switch {
+		case bottom >= renderTop && top <= renderBottom:
+			if !slot.loaded {
+				pending = append(pending, slot)
+			}
+		case (bottom < keepTop || top > keepBottom) && slot.loaded:
+			v.release(slot)
+		}
+	}
+
+	// 离视口越近越先渲染
+	center := offset + height/2
+	sort.SliceStable(pending, func(i, j int) bool {
+		return slotDistance(pending[i], center) < slotDistance(pending[j], center)
+	})
+	for _, slot := range pending {
+		v.queue(slot)
+	}
+
+	if track && current != 0 && v.onPageChanged != nil {
+		v.onPageChanged(current)
+	}
+}
+
+// queue 通过控制器的渲染协程排队渲染一页，结果在界面协程中显示
+func (v *continuousView) queue(slot *pageSlot) {
+	ctx, cancel := context.WithCancel(v.layoutCtx)
+	slot.loaded = true
+	slot.cancel = cancel
+	engine, generation := v.engine, v.generation
+
+	v.controller.QueuePage(ctx, slot.page, v.dpi, slot.opts, func(img image.Image, err error) {
+		if err != nil {
+			return // 保留空白页面
+		}
+
+		// 链接在渲染协程中读取，首次解析整个文档较慢；失败时按无链接处理
+		links, err := engine.GetPageLinks(slot.page)
+		if err != nil {
+			links = nil
+		}
+		fyne.Do(func() {
+			if generation != v.generation || ctx.Err() != nil {
+				return // 渲染期间页面被释放或布局变化
+			}
+			v.show(slot, img, links)
+		})
+	})
+}
+
+// show 显示渲染好的页面及其高亮
+func (v *continuousView) show(slot *pageSlot, img image.Image, links []PageLink) {
+	slot.links = links
+	slot.view.image.Image = img
+	slot.view.image.Refresh()
+	slot.view.overlay.SetRects(v.pageRects(slot.page, slot.placement))
+}
+
+// release 取消页面的渲染并释放图像
+func (v *continuousView) release(slot *pageSlot) {
+	if slot.cancel != nil {
+		slot.cancel()
+		slot.cancel = nil
+	}
+	slot.loaded = false
+	slot.links = nil
+	if slot.view.image.Image != nil {
+		slot.view.image.Image = nil
+		slot.view.image.Refresh()
+		slot.view.overlay.SetRects(nil)
+	}
+}
+
+// pagePoint 将页面控件内的坐标换算为页面坐标
+func (v *continuousView) pagePoint(slot *pageSlot, pos fyne.Position) (x, y float64, ok bool) {
+	x, y, ok = slot.view.overlay.PositionToPagePoint(pos)
+	if !ok {
+		return 0, 0, false
+	}
+	return slot.placement.PagePoint(x, y)
+}
+
+// linkAt 返回页面坐标处的链接
+func (slot *pageSlot) linkAt(x, y float64) *PageLink {
+	for i := range slot.links {
+		if slot.links[i].Contains(x, y) {
+			return &slot.links[i]
+		}
+	}
+	return nil
+}
+
+// tapped 将单击换算为页面坐标并交给标签页处理
+func (v *continuousView) tapped(slot *pageSlot, pos fyne.Position, modifier fyne.KeyModifier) {
+	x, y, ok := v.pagePoint(slot, pos)
+	if !ok || v.onTap == nil {
+		return
+	}
+	v.onTap(slot.page, x, y, slot.linkAt(x, y), modifier)
+}
+
+// hovered 更新鼠标下方的链接，slot 为 nil 表示鼠标离开页面
+func (v *continuousView) hovered(slot *pageSlot, pos fyne.Position) {
+	var link *PageLink
+	if slot != nil {
+		if x, y, ok := v.pagePoint(slot, pos); ok {
+			link = slot.linkAt(x, y)
+		}
+		slot.view.pointer = link != nil
+	}
+	if link == v.hoverLink {
+		return
+	}
+
+	v.hoverLink = link
+	if v.onHover != nil {
+		v.onHover(link)
+	}
+}
+
+// slotDistance 页面与纵坐标 y 的距离
+func slotDistance(slot *pageSlot, y float32) float32 {
+	switch {
+	case y < slot.y:
+		return slot.y - y
+	case y > slot.y+slot.size.Height:
+		return y - slot.y - slot.size.Height
+	}
+	return 0
+}
+
+// pageView 连续模式中的一页：白色底、页面图像和高亮叠加层
+// 单击和悬停换算为页面坐标后交给视图；不处理滚轮，滚动仍由外层的滚动容器负责
+type pageView struct {
+	widget.BaseWidget
+	view       *continuousView
+	slot       *pageSlot
+	background *canvas.Rectangle
+	image      *canvas.Image
+	overlay    *pageOverlay
+	pointer    bool             // 是否显示手形光标（鼠标位于链接上）
+	modifier   fyne.KeyModifier // 最近一次按下鼠标时的修饰键
+}
+
+func newPageView(v *continuousView, slot *pageSlot, dpi int) *pageView {
+	pv := &pageView{
+		view:       v,
+		slot:       slot,
+		background: canvas.NewRectangle(color.White),
+		image:      canvas.NewImageFromImage(nil),
+		overlay:    newPageOverlay(),
+	}
+	pv.background.SetMinSize(slot.size)
+	pv.image.FillMode = canvas.ImageFillContain
+	pv.overlay.SetPage(int(slot.size.Width), int(slot.size.Height), dpi)
+	pv.ExtendBaseWidget(pv)
+	return pv
+}
+
+func (pv *pageView) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(pv.background, pv.image, pv.overlay))
+}
+
+func (pv *pageView) Tapped(ev *fyne.PointEvent) {
+	pv.view.tapped(pv.slot, ev.Position, pv.modifier)
+}
+
+func (pv *pageView) MouseDown(ev *desktop.MouseEvent) {
+	pv.modifier = ev.Modifier
+}
+
+func (pv *pageView) MouseUp(*desktop.MouseEvent) {}
+
+func (pv *pageView) MouseIn(ev *desktop.MouseEvent) {
+	pv.MouseMoved(ev)
+}
+
+func (pv *pageView) MouseMoved(ev *desktop.MouseEvent) {
+	pv.view.hovered(pv.slot, ev.Position)
+}
+
+func (pv *pageView) MouseOut() {
+	pv.pointer = false
+	pv.view.hovered(nil, fyne.Position{})
+}
+
+func (pv *pageView) Cursor() desktop.Cursor {
+	if pv.pointer {
+		return desktop.PointerCursor
+	}
+	return desktop.DefaultCursor
+}
+
+// pagesLayout 将页面纵向排列并水平居中
+type pagesLayout struct {
+	view *continuousView
+}
+
+func (l *pagesLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	y := float32(0)
+	for _, obj := range objects {
+		min := obj.MinSize()
+		obj.Resize(min)
+		obj.Move(fyne.NewPos((size.Width-min.Width)/2, y))
+		y += min.Height + continuousGap
+	}
+
+	// 视口大小变化后可见页面随之变化
+	l.view.scheduleVisible()
+}
+
+func (l *pagesLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var size fyne.Size
+	for i, obj := range objects {
+		min := obj.MinSize()
+		if min.Width > size.Width {
+			size.Width = min.Width
+		}
+		size.Height += min.Height
+		if i > 0 {
+			size.Height += continuousGap
+		}
+	}
+	return size
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+// newTestContinuousView 创建两页（第 2 页旋转 90°）的连续视图，72 DPI 下一点对应一个像素
+func newTestContinuousView(t *testing.T, onTap func(page int, x, y float64, link *PageLink, modifier fyne.KeyModifier)) *continuousView {
+	t.Helper()
+	test.NewTempApp(t)
+
+	v := newContinuousView(NewController(), "", nil, func(int, pagePlacement) []overlayRect { return nil }, onTap, nil)
+	t.Cleanup(v.Stop)
+	v.sizes = []PageSize{{Page: 1, Width: 200, Height: 300}, {Page: 2, Width: 200, Height: 300}}
+	v.rescale(72, []RenderOptions{{}, {Rotation: 90}})
+	v.scroll.Resize(fyne.NewSize(400, 300))
+	return v
+}
+
+func TestContinuousViewTapInPageCoordinates(t *testing.T) {
+	var gotPage int
+	var gotX, gotY float64
+	var gotLink *PageLink
+	v := newTestContinuousView(t, func(page int, x, y float64, link *PageLink, _ fyne.KeyModifier) {
+		gotPage, gotX, gotY, gotLink = page, x, y, link
+	})
+
+	slot := v.slots[1]
+	if slot.y != 300+continuousGap {
+		t.Fatalf("page 2 starts at y = %v, want %v", slot.y, 300+continuousGap)
+	}
+	slot.links = []PageLink{{Rect: PageRect{X: 10, Y: 20, W: 30, H: 40}, Page: 1}}
+
+	// 旋转 90° 后第 2 页显示为 300 × 200，页面上的点 (20, 30) 显示在 (300-30, 20)
+	slot.view.Tapped(&fyne.PointEvent{Position: fyne.NewPos(270, 20)})
+	if gotPage != 2 || math.Abs(gotX-20) > 0.01 || math.Abs(gotY-30) > 0.01 {
+		t.Errorf("tap translated to page %d (%v, %v), want page 2 (20, 30)", gotPage, gotX, gotY)
+	}
+	if gotLink == nil || gotLink.Page != 1 {
+		t.Errorf("tap on a link reported link %v, want the link to page 1", gotLink)
+	}
+
+	gotLink = nil
+	slot.view.Tapped(&fyne.PointEvent{Position: fyne.NewPos(100, 150)})
+	if gotLink != nil {
+		t.Errorf("tap outside the link reported link %v", gotLink)
+	}
+}
+
+func TestContinuousViewHoverLink(t *testing.T) {
+	v := newTestContinuousView(t, nil)
+	var hovered []*PageLink
+	v.onHover = func(link *PageLink) { hovered = append(hovered, link) }
+
+	slot := v.slots[0]
+	slot.links = []PageLink{{Rect: PageRect{X: 10, Y: 20, W: 30, H: 40}, URI: "https://example.com"}}
+
+	move := func(x, y float32) {
+		slot.view.MouseMoved(&desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(x, y)}})
+	}
+	move(20, 30)
+	move(25, 35) // 仍在同一链接上，不重复回调
+	if len(hovered) != 1 || hovered[0] == nil || !slot.view.pointer {
+		t.Fatalf("hovering a link: callbacks %v, pointer %v; want one callback with the link and a pointer cursor", hovered, slot.view.pointer)
+	}
+	slot.view.MouseOut()
+	if len(hovered) != 2 || hovered[1] != nil || slot.view.pointer {
+		t.Errorf("leaving the page: callbacks %v, pointer %v; want a nil link and the default cursor", hovered, slot.view.pointer)
+	}
+}
+
+func TestContinuousViewScrollToRect(t *testing.T) {
+	v := newTestContinuousView(t, nil)
+	v.scroll.Resize(fyne.NewSize(400, 100)) // 视口低于内容，偏移不会被夹住
+
+	// 第 2 页上 (20, 250) 处的矩形旋转后显示在该页的 (300-250-10, 20)
+	v.ScrollToRect(2, PageRect{X: 20, Y: 250, W: 10, H: 10})
+	wantY := float32(300+continuousGap) + 20 - v.scroll.Size().Height/3
+	if got := v.scroll.Offset.Y; math.Abs(float64(got-wantY)) > 0.01 {
+		t.Errorf("ScrollToRect() offset Y = %v, want %v", got, wantY)
+	}
+
+	// 布局完成前记下目标，布局后再滚动
+	v.slots = nil
+	v.ScrollToRect(2, PageRect{X: 20, Y: 250, W: 10, H: 10})
+	if v.target == nil || v.target.Page != 2 {
+		t.Errorf("ScrollToRect() before layout kept target %v, want page 2", v.target)
+	}
+}
@@ -1,204 +1,689 @@
-package main
-
-import (
-	"fmt"
-	"image"
-)
-
-// Controller 管理 PDF 阅读器的状态和逻辑
-type Controller struct {
-	engine      *PDFEngine
-	currentPage int
-	zoomLevel   float64
-	baseDPI     int
-}
-
-// NewController 创建控制器实例
-func NewController() *Controller {
-	return &Controller{
-		currentPage: 1,
-		zoomLevel:   1.0,
-		baseDPI:     150, // 默认 150 DPI
-	}
-}
-
-// OpenPDF 打开 PDF 文件
-func (c *Controller) OpenPDF(filePath string) error {
-	engine, err := NewPDFEngine(filePath)
-	if err != nil {
-		return err
-	}
-
-	c.engine = engine
-	c.currentPage = 1
-	c.zoomLevel = 1.0
-
-	return nil
-}
-
-// HasDocument 检查是否已加载文档
-func (c *Controller) HasDocument() bool {
-	return c.engine != nil
-}
-
-// GetCurrentPage 获取当前页码
-func (c *Controller) GetCurrentPage() int {
-	return c.currentPage
-}
-
-// GetPageCount 获取总页数
-func (c *Controller) GetPageCount() int {
-	if c.engine == nil {
-		return 0
-	}
-	return c.engine.GetPageCount()
-}
-
-// GetZoomLevel 获取缩放级别
-func (c *Controller) GetZoomLevel() float64 {
-	return c.zoomLevel
-}
-
-// NextPage 下一页
-func (c *Controller) NextPage() bool {
-	if c.engine == nil {
-		return false
-	}
-
-	if c.currentPage < c.engine.GetPageCount() {
-		c.currentPage++
-		return true
-	}
-	return false
-}
-
-// PrevPage 上一页
-func (c *Controller) PrevPage() bool {
-	if c.engine == nil {
-		return false
-	}
-
-	if c.currentPage > 1 {
-		c.currentPage--
-		return true
-	}
-	return false
-}
-
-// FirstPage 跳转到第一页
-func (c *Controller) FirstPage() bool {
-	if c.engine == nil {
-		return false
-	}
-
-	if c.currentPage != 1 {
-		c.currentPage = 1
-		return true
-	}
-	return false
-}
-
-// LastPage 跳转到最后一页
-func (c *Controller) LastPage() bool {
-	if c.engine == nil {
-		return false
-	}
-
-	lastPage := c.engine.GetPageCount()
-	if c.currentPage != lastPage {
-		c.currentPage = lastPage
-		return true
-	}
-	return false
-}
-
-// GoToPage 跳转到指定页
-func (c *Controller) GoToPage(pageNum int) error {
-	if c.engine == nil {
-		return fmt.Errorf("未打开文档")
-	}
-
-	if pageNum < 1 || pageNum > c.engine.GetPageCount() {
-		return fmt.Errorf("页码超出范围: %d (1-%d)", pageNum, c.engine.GetPageCount())
-	}
-
-	c.currentPage = pageNum
-	return nil
-}
-
-// SetZoom 设置缩放级别
-func (c *Controller) SetZoom(level float64) {
-	if level < 0.5 {
-		level = 0.5
-	} else if level > 3.0 {
-		level = 3.0
-	}
-	c.zoomLevel = level
-}
-
-// ZoomIn 放大
-func (c *Controller) ZoomIn() {
-	newZoom := c.zoomLevel * 1.25
-	if newZoom > 3.0 {
-		newZoom = 3.0
-	}
-	c.zoomLevel = newZoom
-}
-
-// ZoomOut 缩小
-func (c *Controller) ZoomOut() {
-	newZoom := c.zoomLevel / 1.25
-	if newZoom < 0.5 {
-		newZoom = 0.5
-	}
-	c.zoomLevel = newZoom
-}
-
-// ResetZoom 重置缩放
-func (c *Controller) ResetZoom() {
-	c.zoomLevel = 1.0
-}
-
-// RenderCurrentPage 渲染当前页面
-func (c *Controller) RenderCurrentPage() (image.Image, error) {
-	if c.engine == nil {
-		return nil, fmt.Errorf("未打开文档")
-	}
-
-	dpi := int(float64(c.baseDPI) * c.zoomLevel)
-	return c.engine.RenderPage(c.currentPage, dpi)
-}
-
-// GetStatusText 获取状态栏文本
-func (c *Controller) GetStatusText(tr *Translations) string {
-	if c.engine == nil {
-		return tr.StatusNoDocument
-	}
-
-	// 获取文件名
-	fileName := c.engine.GetFileName()
-
-	// 获取文件大小
-	fileSize, err := c.engine.GetFileSize()
-	fileSizeStr := ""
-	if err == nil {
-		if fileSize < 1024 {
-			fileSizeStr = fmt.Sprintf("%d B", fileSize)
-		} else if fileSize < 1024*1024 {
-			fileSizeStr = fmt.Sprintf("%.1f KB", float64(fileSize)/1024)
-		} else {
-			fileSizeStr = fmt.Sprintf("%.1f MB", float64(fileSize)/(1024*1024))
-		}
-	}
-
-	return fmt.Sprintf("%s  |  %s %d / %d %s  |  %s: %d%%  |  %s: %s",
-		fileName,
-		tr.StatusPage,
-		c.currentPage,
-		c.engine.GetPageCount(),
-		"页",
-		tr.StatusZoom,
-		int(c.zoomLevel*100),
-		tr.StatusSize,
-		fileSizeStr)
-}
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+)
+
+// defaultPrefetchCount 默认预取的相邻页数
+const defaultPrefetchCount = 2
+
+// Controller 管理 PDF 阅读器的状态和逻辑
+type Controller struct {
+	engine      DocumentEngine
+	currentPage int
+	zoomLevel   float64
+	zoomMode    ZoomMode // 手动缩放或自动适配视口
+	baseDPI     int
+	spreadMode  SpreadMode // 单页或双页显示
+
+	// 旋转设置：整个视图的旋转加上各页单独的旋转
+	viewRotation    int
+	pageRotations   map[int]int
+	rotationVersion int // 旋转设置每次变化时递增
+
+	// 预取设置
+	prefetchCount       int  // 预取的相邻页数
+	prefetchDirectional bool // 是否按翻页方向预取
+	direction           int  // 最近一次翻页方向：1 向后，-1 向前
+
+	worker *renderWorker // 当前文档的渲染工作协程
+}
+
+// NewController 创建控制器实例
+func NewController() *Controller {
+	return &Controller{
+		currentPage:         1,
+		zoomLevel:           1.0,
+		baseDPI:             150, // 默认 150 DPI
+		prefetchCount:       defaultPrefetchCount,
+		prefetchDirectional: true,
+		direction:           1,
+	}
+}
+
+// SetPrefetch 设置预取页数和是否按翻页方向预取（count 为 0 时关闭预取）
+func (c *Controller) SetPrefetch(count int, directional bool) {
+	if count < 0 {
+		count = 0
+	}
+	c.prefetchCount = count
+	c.prefetchDirectional = directional
+}
+
+// OpenPDF 打开 PDF 文件
+func (c *Controller) OpenPDF(filePath string) error {
+	return c.OpenPDFWithPassword(filePath, "")
+}
+
+// OpenPDFWithPassword 使用密码打开 PDF 文件
+func (c *Controller) OpenPDFWithPassword(filePath, password string) error {
+	engine, err := NewPDFEngineWithPassword(filePath, password)
+	if err != nil {
+		return err
+	}
+
+	c.setEngine(engine)
+	return nil
+}
+
+// OpenPDFData 从内存数据打开文档（标准输入或非文件 URI）
+func (c *Controller) OpenPDFData(name string, data []byte, password string) error {
+	engine, err := NewPDFEngineFromBytes(name, data, password)
+	if err != nil {
+		return err
+	}
+
+	c.setEngine(engine)
+	return nil
+}
+
+// setEngine 切换到新打开的文档并重置页码和缩放
+func (c *Controller) setEngine(engine DocumentEngine) {
+	c.stopWorker()
+	c.engine = engine
+	c.worker = newRenderWorker(engine)
+	c.currentPage = 1
+	c.zoomLevel = 1.0
+	c.direction = 1
+	c.pageRotations = nil
+	c.rotationVersion++
+}
+
+// reopenDocument 重新打开 current 对应的磁盘文件（文件被其他程序改写后），沿用其密码和排版设置
+// 只打开新引擎而不改动控制器，可以在后台协程中调用，之后在界面协程中用 ApplyReload 替换
+func reopenDocument(current *PDFEngine) (*PDFEngine, error) {
+	if current == nil || current.GetFilePath() == "" {
+		return nil, fmt.Errorf("文档不是从本地文件打开的，无法重新加载")
+	}
+
+	engine, err := NewPDFEngineWithPassword(current.GetFilePath(), current.password)
+	if err != nil {
+		return nil, err
+	}
+	if layout := current.GetLayout(); layout != engine.GetLayout() {
+		if err := engine.SetLayout(layout); err != nil && !errors.Is(err, ErrLayoutUnsupported) {
+			engine.Close()
+			return nil, err
+		}
+	}
+	return engine, nil
+}
+
+// ApplyReload 用重新打开的新版本替换 current，保留页码、缩放、双页和旋转设置
+// 重新打开期间已切换到其他文档时关闭新引擎并返回 false
+func (c *Controller) ApplyReload(current, engine DocumentEngine) bool {
+	if c.engine != current {
+		engine.Close()
+		return false
+	}
+	c.replaceEngine(engine)
+	return true
+}
+
+// replaceEngine 换成同一文档的新版本并关闭旧引擎
+// 页码超出新的页数时移到最后一页，超出范围的单页旋转设置被丢弃
+func (c *Controller) replaceEngine(engine DocumentEngine) {
+	old := c.engine
+	c.stopWorker()
+	c.engine = engine
+	c.worker = newRenderWorker(engine)
+
+	count := engine.GetPageCount()
+	if c.currentPage > count {
+		c.currentPage = count
+	}
+	if c.currentPage < 1 {
+		c.currentPage = 1
+	}
+	c.currentPage = spreadStart(c.currentPage, c.spreadMode)
+
+	for page := range c.pageRotations {
+		if page > count {
+			delete(c.pageRotations, page)
+		}
+	}
+	c.rotationVersion++
+
+	if old != nil {
+		old.Close()
+	}
+}
+
+// Close 停止后台任务并关闭文档
+func (c *Controller) Close() error {
+	c.stopWorker()
+	if c.engine == nil {
+		return nil
+	}
+	return c.engine.Close()
+}
+
+// Engine 返回当前文档的 MuPDF 引擎，用于导出、属性、搜索等只有 PDFEngine 提供的功能
+// 未打开文档或使用其他实现时返回 nil
+func (c *Controller) Engine() *PDFEngine {
+	engine, _ := c.engine.(*PDFEngine)
+	return engine
+}
+
+// HasDocument 检查是否已加载文档
+func (c *Controller) HasDocument() bool {
+	return c.engine != nil
+}
+
+// GetCurrentPage 获取当前页码
+func (c *Controller) GetCurrentPage() int {
+	return c.currentPage
+}
+
+// GetPageCount 获取总页数
+func (c *Controller) GetPageCount() int {
+	if c.engine == nil {
+		return 0
+	}
+	return c.engine.GetPageCount()
+}
+
+// GetZoomLevel 获取缩放级别
+func (c *Controller) GetZoomLevel() float64 {
+	return c.zoomLevel
+}
+
+// GetDPI 获取当前缩放级别下的渲染 DPI
+func (c *Controller) GetDPI() int {
+	return int(float64(c.baseDPI) * c.zoomLevel)
+}
+
+// SetSpreadMode 设置单页或双页显示，当前页调整为所在双页组的第一页
+func (c *Controller) SetSpreadMode(mode SpreadMode) {
+	c.spreadMode = mode
+	c.currentPage = spreadStart(c.currentPage, mode)
+}
+
+// GetSpreadMode 获取单页或双页显示方式
+func (c *Controller) GetSpreadMode() SpreadMode {
+	return c.spreadMode
+}
+
+// GetVisiblePages 获取当前显示的页码，双页模式下可能为两页
+func (c *Controller) GetVisiblePages() []int {
+	if c.engine == nil {
+		return nil
+	}
+	return spreadPages(c.currentPage, c.engine.GetPageCount(), c.spreadMode)
+}
+
+// GetPagePlacements 返回各页在显示图像中的位置和旋转，与 renderSpread 的排列一致
+// 页面尺寸读取失败时只返回第一页
+func (c *Controller) GetPagePlacements(pages []int) map[int]pagePlacement {
+	placements := make(map[int]pagePlacement, len(pages))
+	if c.engine == nil {
+		return placements
+	}
+
+	sizes := make([]PageSize, 0, len(pages))
+	for _, page := range pages {
+		size, err := c.engine.GetPageSize(page)
+		if err != nil {
+			break
+		}
+		sizes = append(sizes, size)
+	}
+
+	displayed := make([]PageSize, len(sizes))
+	for i, size := range sizes {
+		displayed[i] = rotatedSize(size, c.GetRotation(pages[i]))
+	}
+	for i, x := range spreadOffsets(displayed) {
+		placements[pages[i]] = pagePlacement{X: x, Size: sizes[i], Rotation: c.GetRotation(pages[i])}
+	}
+	if len(placements) == 0 && len(pages) > 0 {
+		placements[pages[0]] = pagePlacement{}
+	}
+	return placements
+}
+
+// NextPage 下一页（双页模式下为下一组）
+func (c *Controller) NextPage() bool {
+	if c.engine == nil {
+		return false
+	}
+
+	next := c.currentPage + len(c.GetVisiblePages())
+	if next <= c.engine.GetPageCount() {
+		c.currentPage = next
+		c.direction = 1
+		return true
+	}
+	return false
+}
+
+// PrevPage 上一页（双页模式下为上一组）
+func (c *Controller) PrevPage() bool {
+	if c.engine == nil {
+		return false
+	}
+
+	if c.currentPage > 1 {
+		c.currentPage = spreadStart(c.currentPage-1, c.spreadMode)
+		c.direction = -1
+		return true
+	}
+	return false
+}
+
+// FirstPage 跳转到第一页
+func (c *Controller) FirstPage() bool {
+	if c.engine == nil {
+		return false
+	}
+
+	if c.currentPage != 1 {
+		c.currentPage = 1
+		c.direction = 1
+		return true
+	}
+	return false
+}
+
+// LastPage 跳转到最后一页
+func (c *Controller) LastPage() bool {
+	if c.engine == nil {
+		return false
+	}
+
+	lastPage := spreadStart(c.engine.GetPageCount(), c.spreadMode)
+	if c.currentPage != lastPage {
+		c.currentPage = lastPage
+		c.direction = -1
+		return true
+	}
+	return false
+}
+
+// GoToPage 跳转到指定页，双页模式下跳转到包含该页的双页组
+func (c *Controller) GoToPage(pageNum int) error {
+	if c.engine == nil {
+		return fmt.Errorf("未打开文档")
+	}
+
+	if pageNum < 1 || pageNum > c.engine.GetPageCount() {
+		return fmt.Errorf("页码超出范围: %d (1-%d)", pageNum, c.engine.GetPageCount())
+	}
+
+	pageNum = spreadStart(pageNum, c.spreadMode)
+	if pageNum < c.currentPage {
+		c.direction = -1
+	} else if pageNum > c.currentPage {
+		c.direction = 1
+	}
+	c.currentPage = pageNum
+	return nil
+}
+
+// SetZoom 设置缩放级别，并退出自动适配
+func (c *Controller) SetZoom(level float64) {
+	if floor := c.zoomFloor(); level < floor {
+		level = floor
+	} else if level > maxZoom {
+		level = maxZoom
+	}
+	c.zoomLevel = level
+	c.zoomMode = ZoomCustom
+}
+
+// ZoomIn 放大，并退出自动适配
+func (c *Controller) ZoomIn() {
+	newZoom := c.zoomLevel * 1.25
+	if newZoom > maxZoom {
+		newZoom = maxZoom
+	}
+	c.zoomLevel = newZoom
+	c.zoomMode = ZoomCustom
+}
+
+// ZoomOut 缩小，并退出自动适配
+func (c *Controller) ZoomOut() {
+	newZoom := c.zoomLevel / 1.25
+	if floor := c.zoomFloor(); newZoom < floor {
+		newZoom = floor
+	}
+	c.zoomLevel = newZoom
+	c.zoomMode = ZoomCustom
+}
+
+// zoomFloor 手动缩放的下限
+// 自动适配已缩到 minZoom 以下时允许继续缩小到 minFitZoom，避免缩小反而把页面放大
+func (c *Controller) zoomFloor() float64 {
+	if c.zoomLevel < minZoom {
+		return minFitZoom
+	}
+	return minZoom
+}
+
+// ResetZoom 重置缩放，并退出自动适配
+func (c *Controller) ResetZoom() {
+	c.zoomLevel = 1.0
+	c.zoomMode = ZoomCustom
+}
+
+// SetZoomMode 设置缩放方式，自动适配模式下需随后调用 FitToViewport
+func (c *Controller) SetZoomMode(mode ZoomMode) {
+	c.zoomMode = mode
+}
+
+// GetZoomMode 获取缩放方式
+func (c *Controller) GetZoomMode() ZoomMode {
+	return c.zoomMode
+}
+
+// FitToViewport 在自动适配模式下按视口大小（像素）计算缩放级别
+// 以当前显示的页（双页模式下为两页并排的整体）为准，DPI 改变时返回 true
+func (c *Controller) FitToViewport(width, height float64) bool {
+	if c.engine == nil || c.zoomMode == ZoomCustom {
+		return false
+	}
+
+	var pageWidth, pageHeight float64
+	for i, page := range c.GetVisiblePages() {
+		size, err := c.engine.GetPageSize(page)
+		if err != nil {
+			return false
+		}
+		size = rotatedSize(size, c.GetRotation(page))
+		if i > 0 {
+			pageWidth += spreadGap
+		}
+		pageWidth += size.Width
+		if size.Height > pageHeight {
+			pageHeight = size.Height
+		}
+	}
+
+	dpi, ok := fitDPI(c.zoomMode, pageWidth, pageHeight, width, height)
+	if !ok {
+		return false
+	}
+
+	level := dpi / float64(c.baseDPI)
+	if level < minFitZoom {
+		level = minFitZoom
+	} else if level > maxZoom {
+		level = maxZoom
+	}
+
+	oldDPI := c.GetDPI()
+	c.zoomLevel = level
+	return c.GetDPI() != oldDPI
+}
+
+// RenderCurrentPage 异步渲染当前页面（双页模式下为拼接后的两页），并在之后预取相邻页面
+// 渲染串行执行，只有最新一次请求的结果会回调 done
+func (c *Controller) RenderCurrentPage(ctx context.Context, done func(image.Image, error)) {
+	if c.engine == nil {
+		done(nil, fmt.Errorf("未打开文档"))
+		return
+	}
+
+	c.worker.Submit(ctx, c.renderTargets(c.GetVisiblePages()), c.GetDPI(), c.renderTargets(c.prefetchPages()), done)
+}
+
+// QueuePage 排队渲染一页（连续模式），不会使其他请求作废；done 在渲染协程中调用
+// opts 由调用方给出，与其布局时使用的旋转保持一致
+func (c *Controller) QueuePage(ctx context.Context, page int, dpi int, opts RenderOptions, done func(image.Image, error)) {
+	if c.engine == nil {
+		done(nil, fmt.Errorf("未打开文档"))
+		return
+	}
+
+	c.worker.Enqueue(ctx, renderTarget{page: page, opts: opts}, dpi, done)
+}
+
+// renderTargets 为各页附上当前的旋转设置
+func (c *Controller) renderTargets(pages []int) []renderTarget {
+	if len(pages) == 0 {
+		return nil
+	}
+	targets := make([]renderTarget, len(pages))
+	for i, page := range pages {
+		targets[i] = renderTarget{page: page, opts: c.GetRenderOptions(page)}
+	}
+	return targets
+}
+
+// RotateView 将整个视图顺时针旋转 delta 度（逆时针为负）
+func (c *Controller) RotateView(delta int) {
+	c.viewRotation = normalizeRotation(c.viewRotation + delta)
+	c.rotationVersion++
+}
+
+// RotatePages 将当前显示的页（双页模式下为两页）顺时针旋转 delta 度
+// 各页的旋转在文档打开期间保留
+func (c *Controller) RotatePages(delta int) {
+	if c.pageRotations == nil {
+		c.pageRotations = map[int]int{}
+	}
+	for _, page := range c.GetVisiblePages() {
+		rotation := normalizeRotation(c.pageRotations[page] + delta)
+		if rotation == 0 {
+			delete(c.pageRotations, page)
+		} else {
+			c.pageRotations[page] = rotation
+		}
+	}
+	c.rotationVersion++
+}
+
+// GetRotation 获取指定页的实际旋转角度（视图旋转加页面旋转）
+func (c *Controller) GetRotation(page int) int {
+	return normalizeRotation(c.viewRotation + c.pageRotations[page])
+}
+
+// GetRenderOptions 获取指定页的渲染选项
+func (c *Controller) GetRenderOptions(page int) RenderOptions {
+	return RenderOptions{Rotation: c.GetRotation(page)}
+}
+
+// GetRotationVersion 旋转设置的版本号，用于判断是否需要重新布局
+func (c *Controller) GetRotationVersion() int {
+	return c.rotationVersion
+}
+
+// GetPageSize 获取指定页面的尺寸（单位：点）
+func (c *Controller) GetPageSize(pageNum int) (PageSize, error) {
+	if c.engine == nil {
+		return PageSize{}, fmt.Errorf("未打开文档")
+	}
+	return c.engine.GetPageSize(pageNum)
+}
+
+// GetCurrentPageText 提取当前页面的文本
+func (c *Controller) GetCurrentPageText() (string, error) {
+	if c.engine == nil {
+		return "", fmt.Errorf("未打开文档")
+	}
+	return c.engine.GetPageText(c.currentPage)
+}
+
+// GetOutline 获取文档目录
+func (c *Controller) GetOutline() ([]OutlineItem, error) {
+	if c.engine == nil {
+		return nil, fmt.Errorf("未打开文档")
+	}
+	return c.engine.GetOutline()
+}
+
+// GetPageLinks 获取指定页面的链接
+func (c *Controller) GetPageLinks(pageNum int) ([]PageLink, error) {
+	if c.engine == nil {
+		return nil, fmt.Errorf("未打开文档")
+	}
+	return c.engine.GetPageLinks(pageNum)
+}
+
+// ForwardSearch 正向搜索：跳转到源文件位置对应的页面，返回该行在页面上的区域
+func (c *Controller) ForwardSearch(loc SourceLocation) ([]SyncBox, error) {
+	engine := c.Engine()
+	if engine == nil {
+		return nil, ErrNoSyncTeX
+	}
+	idx, err := engine.GetSyncTeX()
+	if err != nil {
+		return nil, err
+	}
+
+	boxes, err := idx.Forward(loc)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.GoToPage(boxes[0].Page); err != nil {
+		return nil, err
+	}
+	return boxes, nil
+}
+
+// InverseSearch 反向搜索：返回页面坐标处对应的源文件位置
+func (c *Controller) InverseSearch(page int, x, y float64) (SourceLocation, error) {
+	engine := c.Engine()
+	if engine == nil {
+		return SourceLocation{}, ErrNoSyncTeX
+	}
+	idx, err := engine.GetSyncTeX()
+	if err != nil {
+		return SourceLocation{}, err
+	}
+
+	loc, ok := idx.Inverse(page, x, y)
+	if !ok {
+		return SourceLocation{}, fmt.Errorf("第 %d 页上没有 SyncTeX 记录", page)
+	}
+	return loc, nil
+}
+
+// GetFormat 获取当前文档格式
+func (c *Controller) GetFormat() DocumentFormat {
+	if c.engine == nil {
+		return ""
+	}
+	return c.engine.GetFormat()
+}
+
+// SetLayout 按新的版面大小和字号重新排版可重排文档，页码按原页数比例保留
+func (c *Controller) SetLayout(opts LayoutOptions) error {
+	if c.engine == nil {
+		return fmt.Errorf("未打开文档")
+	}
+
+	oldCount := c.engine.GetPageCount()
+
+	// 重新排版期间停止渲染协程，排版完成后的请求按新的页数渲染
+	c.stopWorker()
+	err := c.engine.SetLayout(opts)
+	c.worker = newRenderWorker(c.engine)
+	if err != nil {
+		return err
+	}
+
+	newCount := c.engine.GetPageCount()
+	if oldCount > 0 && newCount != oldCount {
+		c.currentPage = (c.currentPage-1)*newCount/oldCount + 1
+	}
+	if c.currentPage > newCount {
+		c.currentPage = newCount
+	}
+	if c.currentPage < 1 {
+		c.currentPage = 1
+	}
+	c.currentPage = spreadStart(c.currentPage, c.spreadMode)
+	return nil
+}
+
+// stopWorker 停止渲染工作协程
+func (c *Controller) stopWorker() {
+	if c.worker != nil {
+		c.worker.Stop()
+		c.worker = nil
+	}
+}
+
+// prefetchPages 按优先级返回需要预取的页码
+func (c *Controller) prefetchPages() []int {
+	if c.engine == nil || c.prefetchCount == 0 {
+		return nil
+	}
+
+	pageCount := c.engine.GetPageCount()
+	var pages []int
+	add := func(page int) {
+		if page >= 1 && page <= pageCount {
+			pages = append(pages, page)
+		}
+	}
+
+	// 以当前显示的页为界向前后扩展，双页模式下预取的页数按组加倍
+	visible := c.GetVisiblePages()
+	first, last := visible[0], visible[len(visible)-1]
+	count := c.prefetchCount
+	if c.spreadMode != SpreadOff {
+		count *= 2
+	}
+	after := func(i int) int { return last + i }
+	before := func(i int) int { return first - i }
+
+	if c.prefetchDirectional {
+		// 沿翻页方向预取 N 页，反方向只预取 1 页
+		ahead, behind := after, before
+		if c.direction < 0 {
+			ahead, behind = before, after
+		}
+		for i := 1; i <= count; i++ {
+			add(ahead(i))
+		}
+		add(behind(1))
+	} else {
+		// 前后交替预取
+		for i := 1; i <= count; i++ {
+			add(after(i))
+			add(before(i))
+		}
+	}
+
+	return pages
+}
+
+// GetStatusText 获取状态栏文本
+func (c *Controller) GetStatusText(tr *Translations) string {
+	if c.engine == nil {
+		return tr.StatusNoDocument
+	}
+
+	// 获取文件名
+	fileName := c.engine.GetFileName()
+
+	// 获取文件大小
+	fileSize, err := c.engine.GetFileSize()
+	fileSizeStr := ""
+	if err == nil {
+		fileSizeStr = formatFileSize(fileSize)
+	}
+
+	pageText := fmt.Sprintf(tr.StatusPage, c.currentPage, c.engine.GetPageCount())
+	if visible := c.GetVisiblePages(); len(visible) > 1 {
+		pageText = fmt.Sprintf(tr.StatusPages, visible[0], visible[len(visible)-1], c.engine.GetPageCount())
+	}
+
+	status := fmt.Sprintf("%s  |  %s  |  %s  |  %s: %d%%  |  %s: %s",
+		fileName,
+		c.engine.GetFormat(),
+		pageText,
+		tr.StatusZoom,
+		int(c.zoomLevel*100),
+		tr.StatusSize,
+		fileSizeStr)
+	if rotation := c.GetRotation(c.currentPage); rotation != 0 {
+		status += "  |  " + fmt.Sprintf(tr.StatusRotation, rotation)
+	}
+	return status
+}
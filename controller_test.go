@@ -0,0 +1,920 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestController 创建加载了假文档的控制器
+func newTestController(t *testing.T, engine *fakeEngine) *Controller {
+	t.Helper()
+	c := NewController()
+	c.setEngine(engine)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestControllerWithoutDocument(t *testing.T) {
+	c := NewController()
+
+	if c.HasDocument() {
+		t.Fatal("HasDocument() = true, want false")
+	}
+	if got := c.GetPageCount(); got != 0 {
+		t.Errorf("GetPageCount() = %d, want 0", got)
+	}
+	if c.NextPage() || c.PrevPage() || c.FirstPage() || c.LastPage() {
+		t.Error("navigation without a document should return false")
+	}
+	if err := c.GoToPage(1); err == nil {
+		t.Error("GoToPage(1) without a document should fail")
+	}
+	if c.Engine() != nil {
+		t.Error("Engine() without a document should be nil")
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+
+	done := make(chan error, 1)
+	c.RenderCurrentPage(context.Background(), func(img image.Image, err error) {
+		done <- err
+	})
+	if err := <-done; err == nil {
+		t.Error("RenderCurrentPage without a document should fail")
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	c := newTestController(t, newFakeEngine(3))
+
+	for want := 2; want <= 3; want++ {
+		if !c.NextPage() {
+			t.Fatalf("NextPage() = false before reaching page %d", want)
+		}
+		if got := c.GetCurrentPage(); got != want {
+			t.Fatalf("GetCurrentPage() = %d, want %d", got, want)
+		}
+	}
+
+	if c.NextPage() {
+		t.Error("NextPage() on the last page = true, want false")
+	}
+	if got := c.GetCurrentPage(); got != 3 {
+		t.Errorf("GetCurrentPage() after NextPage on last page = %d, want 3", got)
+	}
+}
+
+func TestPrevPage(t *testing.T) {
+	c := newTestController(t, newFakeEngine(3))
+
+	if c.PrevPage() {
+		t.Error("PrevPage() on the first page = true, want false")
+	}
+	if got := c.GetCurrentPage(); got != 1 {
+		t.Errorf("GetCurrentPage() after PrevPage on first page = %d, want 1", got)
+	}
+
+	c.LastPage()
+	for want := 2; want >= 1; want-- {
+		if !c.PrevPage() {
+			t.Fatalf("PrevPage() = false before reaching page %d", want)
+		}
+		if got := c.GetCurrentPage(); got != want {
+			t.Fatalf("GetCurrentPage() = %d, want %d", got, want)
+		}
+	}
+	if c.PrevPage() {
+		t.Error("PrevPage() back on the first page = true, want false")
+	}
+}
+
+func TestFirstAndLastPage(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+
+	if c.FirstPage() {
+		t.Error("FirstPage() on the first page = true, want false")
+	}
+	if !c.LastPage() {
+		t.Error("LastPage() = false, want true")
+	}
+	if got := c.GetCurrentPage(); got != 10 {
+		t.Errorf("GetCurrentPage() after LastPage = %d, want 10", got)
+	}
+	if c.LastPage() {
+		t.Error("LastPage() on the last page = true, want false")
+	}
+	if !c.FirstPage() {
+		t.Error("FirstPage() = false, want true")
+	}
+	if got := c.GetCurrentPage(); got != 1 {
+		t.Errorf("GetCurrentPage() after FirstPage = %d, want 1", got)
+	}
+}
+
+func TestSinglePageDocument(t *testing.T) {
+	c := newTestController(t, newFakeEngine(1))
+
+	if c.NextPage() || c.PrevPage() || c.FirstPage() || c.LastPage() {
+		t.Error("navigation in a single-page document should return false")
+	}
+	if got := c.GetCurrentPage(); got != 1 {
+		t.Errorf("GetCurrentPage() = %d, want 1", got)
+	}
+}
+
+func TestGoToPageBounds(t *testing.T) {
+	tests := []struct {
+		page    int
+		wantErr bool
+	}{
+		{page: -1, wantErr: true},
+		{page: 0, wantErr: true},
+		{page: 1},
+		{page: 25},
+		{page: 50},
+		{page: 51, wantErr: true},
+		{page: 1000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		c := newTestController(t, newFakeEngine(50))
+		c.GoToPage(10)
+
+		err := c.GoToPage(tt.page)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GoToPage(%d) error = %v, wantErr %v", tt.page, err, tt.wantErr)
+			continue
+		}
+
+		want := tt.page
+		if tt.wantErr {
+			want = 10 // 失败时页码不变
+		}
+		if got := c.GetCurrentPage(); got != want {
+			t.Errorf("GoToPage(%d): GetCurrentPage() = %d, want %d", tt.page, got, want)
+		}
+	}
+}
+
+func TestGoToPageSetsPrefetchDirection(t *testing.T) {
+	c := newTestController(t, newFakeEngine(20))
+	c.SetPrefetch(2, true)
+
+	c.GoToPage(10)
+	if got, want := c.prefetchPages(), []int{11, 12, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prefetchPages() after moving forward = %v, want %v", got, want)
+	}
+
+	c.GoToPage(5)
+	if got, want := c.prefetchPages(), []int{4, 3, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prefetchPages() after moving backward = %v, want %v", got, want)
+	}
+}
+
+func TestPrefetchPages(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int
+		directional bool
+		page        int
+		want        []int
+	}{
+		{name: "disabled", count: 0, directional: true, page: 5, want: nil},
+		{name: "alternating", count: 2, directional: false, page: 5, want: []int{6, 4, 7, 3}},
+		{name: "clipped at start", count: 2, directional: false, page: 1, want: []int{2, 3}},
+		{name: "clipped at end", count: 2, directional: true, page: 10, want: []int{9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(t, newFakeEngine(10))
+			c.SetPrefetch(tt.count, tt.directional)
+			c.GoToPage(tt.page)
+
+			if got := c.prefetchPages(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("prefetchPages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpreadNavigation(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    SpreadMode
+		forward [][]int // 从第 1 页起每次 NextPage 后显示的页
+	}{
+		{name: "facing", mode: SpreadFacing, forward: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "cover", mode: SpreadCover, forward: [][]int{{1}, {2, 3}, {4, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(t, newFakeEngine(5))
+			c.SetSpreadMode(tt.mode)
+
+			for i, want := range tt.forward {
+				if i > 0 && !c.NextPage() {
+					t.Fatalf("NextPage() = false before reaching %v", want)
+				}
+				if got := c.GetVisiblePages(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("GetVisiblePages() = %v, want %v", got, want)
+				}
+			}
+			if c.NextPage() {
+				t.Error("NextPage() on the last spread should return false")
+			}
+
+			for i := len(tt.forward) - 2; i >= 0; i-- {
+				if !c.PrevPage() {
+					t.Fatalf("PrevPage() = false before reaching %v", tt.forward[i])
+				}
+				if got := c.GetVisiblePages(); !reflect.DeepEqual(got, tt.forward[i]) {
+					t.Fatalf("GetVisiblePages() = %v, want %v", got, tt.forward[i])
+				}
+			}
+			if c.PrevPage() {
+				t.Error("PrevPage() on the first spread should return false")
+			}
+		})
+	}
+}
+
+func TestSpreadGoToPage(t *testing.T) {
+	tests := []struct {
+		mode     SpreadMode
+		target   int
+		wantPage int
+		wantLast int // LastPage 后的当前页
+	}{
+		{mode: SpreadFacing, target: 4, wantPage: 3, wantLast: 5},
+		{mode: SpreadFacing, target: 5, wantPage: 5, wantLast: 5},
+		{mode: SpreadCover, target: 1, wantPage: 1, wantLast: 4},
+		{mode: SpreadCover, target: 3, wantPage: 2, wantLast: 4},
+	}
+
+	for _, tt := range tests {
+		c := newTestController(t, newFakeEngine(5))
+		c.SetSpreadMode(tt.mode)
+
+		if err := c.GoToPage(tt.target); err != nil {
+			t.Fatalf("mode %d: GoToPage(%d) error = %v", tt.mode, tt.target, err)
+		}
+		if got := c.GetCurrentPage(); got != tt.wantPage {
+			t.Errorf("mode %d: GoToPage(%d) landed on %d, want %d", tt.mode, tt.target, got, tt.wantPage)
+		}
+
+		c.LastPage()
+		if got := c.GetCurrentPage(); got != tt.wantLast {
+			t.Errorf("mode %d: LastPage() landed on %d, want %d", tt.mode, got, tt.wantLast)
+		}
+	}
+}
+
+func TestSetSpreadModeKeepsSpread(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+	c.GoToPage(6)
+
+	c.SetSpreadMode(SpreadFacing)
+	if got, want := c.GetVisiblePages(), []int{5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetVisiblePages() = %v, want %v", got, want)
+	}
+
+	c.SetSpreadMode(SpreadOff)
+	if got, want := c.GetVisiblePages(), []int{5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetVisiblePages() after leaving spread mode = %v, want %v", got, want)
+	}
+}
+
+func TestSpreadPrefetchAndStatus(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+	c.SetPrefetch(1, true)
+	c.SetSpreadMode(SpreadFacing)
+	c.GoToPage(3)
+
+	if got, want := c.prefetchPages(), []int{5, 6, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prefetchPages() = %v, want %v", got, want)
+	}
+
+	tr := GetTranslations(LangEnglish)
+	want := "document.pdf  |  PDF  |  Pages 3-4 / 10  |  Zoom: 100%  |  Size: 3.2 MB"
+	if got := c.GetStatusText(tr); got != want {
+		t.Errorf("GetStatusText() =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestSetZoomClamps(t *testing.T) {
+	tests := []struct {
+		level float64
+		want  float64
+	}{
+		{level: 0, want: 0.5},
+		{level: 0.1, want: 0.5},
+		{level: 0.5, want: 0.5},
+		{level: 1.0, want: 1.0},
+		{level: 2.25, want: 2.25},
+		{level: 3.0, want: 3.0},
+		{level: 3.01, want: 3.0},
+		{level: 10, want: 3.0},
+	}
+
+	c := NewController()
+	for _, tt := range tests {
+		c.SetZoom(tt.level)
+		if got := c.GetZoomLevel(); got != tt.want {
+			t.Errorf("SetZoom(%v): GetZoomLevel() = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestZoomInClampsAtMaximum(t *testing.T) {
+	c := NewController()
+
+	for i := 0; i < 20; i++ {
+		c.ZoomIn()
+		if got := c.GetZoomLevel(); got > 3.0 {
+			t.Fatalf("ZoomIn() step %d: zoom %v exceeds 3.0", i, got)
+		}
+	}
+	if got := c.GetZoomLevel(); got != 3.0 {
+		t.Errorf("GetZoomLevel() after repeated ZoomIn = %v, want 3.0", got)
+	}
+}
+
+func TestZoomOutClampsAtMinimum(t *testing.T) {
+	c := NewController()
+
+	for i := 0; i < 20; i++ {
+		c.ZoomOut()
+		if got := c.GetZoomLevel(); got < 0.5 {
+			t.Fatalf("ZoomOut() step %d: zoom %v below 0.5", i, got)
+		}
+	}
+	if got := c.GetZoomLevel(); got != 0.5 {
+		t.Errorf("GetZoomLevel() after repeated ZoomOut = %v, want 0.5", got)
+	}
+}
+
+func TestZoomOutBelowFitZoom(t *testing.T) {
+	c := newTestController(t, newFakeEngine(4))
+	c.SetZoomMode(ZoomFitPage)
+	if !c.FitToViewport(300, 300) {
+		t.Fatal("FitToViewport() = false, want true")
+	}
+	fit := c.GetZoomLevel()
+	if fit >= minZoom {
+		t.Fatalf("zoom after fitting a small viewport = %v, want below %v", fit, minZoom)
+	}
+
+	// 适配后的缩放已低于 minZoom，缩小不应把页面放大
+	c.ZoomOut()
+	if got := c.GetZoomLevel(); got >= fit {
+		t.Errorf("ZoomOut() from fit zoom %v gave %v, want a smaller zoom", fit, got)
+	}
+	if c.GetZoomMode() != ZoomCustom {
+		t.Error("ZoomOut() should leave fit mode")
+	}
+
+	for i := 0; i < 20; i++ {
+		c.ZoomOut()
+	}
+	if got := c.GetZoomLevel(); got != minFitZoom {
+		t.Errorf("GetZoomLevel() after repeated ZoomOut = %v, want %v", got, minFitZoom)
+	}
+
+	c.SetZoom(0.2)
+	if got := c.GetZoomLevel(); got != 0.2 {
+		t.Errorf("SetZoom(0.2) below minZoom after fitting = %v, want 0.2", got)
+	}
+}
+
+func TestZoomStepsAndReset(t *testing.T) {
+	c := NewController()
+
+	c.ZoomIn()
+	if got := c.GetZoomLevel(); got != 1.25 {
+		t.Errorf("GetZoomLevel() after ZoomIn = %v, want 1.25", got)
+	}
+	c.ZoomOut()
+	c.ZoomOut()
+	if got := c.GetZoomLevel(); got != 0.8 {
+		t.Errorf("GetZoomLevel() after ZoomIn, ZoomOut, ZoomOut = %v, want 0.8", got)
+	}
+	c.ResetZoom()
+	if got := c.GetZoomLevel(); got != 1.0 {
+		t.Errorf("GetZoomLevel() after ResetZoom = %v, want 1.0", got)
+	}
+}
+
+func TestFitToViewport(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          ZoomMode
+		spread        SpreadMode
+		width, height float64
+		wantDPI       int
+	}{
+		// A4 为 595 × 842 点
+		{name: "fit width", mode: ZoomFitWidth, width: 1190, height: 500, wantDPI: 144},
+		{name: "fit height", mode: ZoomFitHeight, width: 500, height: 1684, wantDPI: 144},
+		{name: "fit page limited by height", mode: ZoomFitPage, width: 1190, height: 842, wantDPI: 72},
+		{name: "fit page limited by width", mode: ZoomFitPage, width: 595, height: 5000, wantDPI: 72},
+		{name: "fit width spread", mode: ZoomFitWidth, spread: SpreadFacing, width: 2 * (595*2 + spreadGap), height: 500, wantDPI: 144},
+		{name: "clamped to minimum", mode: ZoomFitPage, width: 10, height: 10, wantDPI: 15},
+		{name: "clamped to maximum", mode: ZoomFitWidth, width: 100000, height: 500, wantDPI: 450},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(t, newFakeEngine(4))
+			c.SetSpreadMode(tt.spread)
+			c.SetZoomMode(tt.mode)
+
+			if !c.FitToViewport(tt.width, tt.height) {
+				t.Fatal("FitToViewport() = false, want true")
+			}
+			if got := c.GetDPI(); got != tt.wantDPI {
+				t.Errorf("GetDPI() = %d, want %d", got, tt.wantDPI)
+			}
+			if c.FitToViewport(tt.width, tt.height) {
+				t.Error("FitToViewport() with an unchanged viewport = true, want false")
+			}
+		})
+	}
+}
+
+func TestManualZoomLeavesFitMode(t *testing.T) {
+	c := newTestController(t, newFakeEngine(4))
+	c.SetZoomMode(ZoomFitWidth)
+	c.FitToViewport(1190, 500)
+
+	c.ZoomIn()
+	if got := c.GetZoomMode(); got != ZoomCustom {
+		t.Fatalf("GetZoomMode() after ZoomIn = %d, want ZoomCustom", got)
+	}
+	dpi := c.GetDPI()
+	if c.FitToViewport(595, 500) || c.GetDPI() != dpi {
+		t.Error("FitToViewport() in custom mode should not change the zoom")
+	}
+}
+
+func TestGetDPI(t *testing.T) {
+	c := NewController()
+
+	tests := []struct {
+		zoom float64
+		want int
+	}{
+		{zoom: 0.5, want: 75},
+		{zoom: 1.0, want: 150},
+		{zoom: 2.0, want: 300},
+		{zoom: 3.0, want: 450},
+	}
+	for _, tt := range tests {
+		c.SetZoom(tt.zoom)
+		if got := c.GetDPI(); got != tt.want {
+			t.Errorf("zoom %v: GetDPI() = %d, want %d", tt.zoom, got, tt.want)
+		}
+	}
+}
+
+func TestSetEngineResetsState(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+	c.GoToPage(7)
+	c.SetZoom(2.0)
+
+	c.setEngine(newFakeEngine(3))
+	if got := c.GetCurrentPage(); got != 1 {
+		t.Errorf("GetCurrentPage() after opening a new document = %d, want 1", got)
+	}
+	if got := c.GetZoomLevel(); got != 1.0 {
+		t.Errorf("GetZoomLevel() after opening a new document = %v, want 1.0", got)
+	}
+	if got := c.GetPageCount(); got != 3 {
+		t.Errorf("GetPageCount() = %d, want 3", got)
+	}
+}
+
+func TestCloseClosesEngine(t *testing.T) {
+	engine := newFakeEngine(3)
+	c := NewController()
+	c.setEngine(engine)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if !engine.closed {
+		t.Error("Close() did not close the engine")
+	}
+}
+
+func TestReplaceEngineKeepsState(t *testing.T) {
+	old := newFakeEngine(10)
+	c := newTestController(t, old)
+	c.SetSpreadMode(SpreadFacing)
+	c.GoToPage(5)
+	c.SetZoom(2.0)
+	c.RotateView(90)
+	c.RotatePages(90)
+	version := c.GetRotationVersion()
+
+	c.replaceEngine(newFakeEngine(12))
+	if !old.closed {
+		t.Error("replaceEngine() did not close the old engine")
+	}
+	if got := c.GetCurrentPage(); got != 5 {
+		t.Errorf("GetCurrentPage() after reload = %d, want 5", got)
+	}
+	if got := c.GetZoomLevel(); got != 2.0 {
+		t.Errorf("GetZoomLevel() after reload = %v, want 2.0", got)
+	}
+	if got := c.GetSpreadMode(); got != SpreadFacing {
+		t.Errorf("GetSpreadMode() after reload = %v, want SpreadFacing", got)
+	}
+	if got := c.GetRotation(6); got != 180 {
+		t.Errorf("GetRotation(6) after reload = %d, want 180", got)
+	}
+	if c.GetRotationVersion() == version {
+		t.Error("replaceEngine() did not bump the rotation version")
+	}
+}
+
+func TestReplaceEngineClampsPage(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+	c.SetSpreadMode(SpreadFacing)
+	c.LastPage()
+	c.RotatePages(90)
+
+	// 新版本只剩 6 页：停在最后一组，已不存在的页的旋转设置被丢弃
+	c.replaceEngine(newFakeEngine(6))
+	if got := c.GetCurrentPage(); got != 5 {
+		t.Errorf("GetCurrentPage() after shrinking reload = %d, want 5", got)
+	}
+	if len(c.pageRotations) != 0 {
+		t.Errorf("pageRotations after shrinking reload = %v, want empty", c.pageRotations)
+	}
+}
+
+func TestReloadWithoutFile(t *testing.T) {
+	if _, err := reopenDocument(nil); err == nil {
+		t.Error("reopenDocument(nil) = nil error, want error")
+	}
+
+	engine, err := NewPDFEngineFromBytes("memory.pdf", buildTestPDF("/Title (Memory)"), "")
+	if err != nil {
+		t.Fatalf("NewPDFEngineFromBytes() = %v", err)
+	}
+	defer engine.Close()
+	if _, err := reopenDocument(engine); err == nil {
+		t.Error("reopenDocument() of a document opened from memory = nil error, want error")
+	}
+}
+
+func TestReloadFromDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paper.pdf")
+	if err := os.WriteFile(path, buildTestPDF("/Title (First)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewController()
+	t.Cleanup(func() { c.Close() })
+	if err := c.OpenPDF(path); err != nil {
+		t.Fatalf("OpenPDF() = %v", err)
+	}
+	current := c.Engine()
+
+	// 文件被改写后在后台重新打开，替换前控制器仍使用旧文档
+	if err := os.WriteFile(path, buildTestPDF("/Title (Second)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := reopenDocument(current)
+	if err != nil {
+		t.Fatalf("reopenDocument() = %v", err)
+	}
+	if c.Engine() != current {
+		t.Fatal("reopenDocument() replaced the controller's document")
+	}
+
+	if !c.ApplyReload(current, engine) {
+		t.Fatal("ApplyReload() = false, want true")
+	}
+	if c.Engine() != engine {
+		t.Error("ApplyReload() did not switch to the reopened document")
+	}
+	info, err := c.Engine().GetInfo()
+	if err != nil {
+		t.Fatalf("GetInfo() after reload = %v", err)
+	}
+	if info.Title != "Second" {
+		t.Errorf("title after reload = %q, want \"Second\"", info.Title)
+	}
+}
+
+func TestApplyReloadAfterSwitch(t *testing.T) {
+	c := newTestController(t, newFakeEngine(3))
+	stale := newFakeEngine(3)
+	reopened := newFakeEngine(4)
+
+	// 重新打开期间用户打开了其他文档：丢弃新版本，不替换当前文档
+	if c.ApplyReload(stale, reopened) {
+		t.Error("ApplyReload() for a document that is no longer shown = true, want false")
+	}
+	if !reopened.closed {
+		t.Error("ApplyReload() did not close the discarded engine")
+	}
+	if got := c.GetPageCount(); got != 3 {
+		t.Errorf("GetPageCount() = %d, want 3", got)
+	}
+}
+
+func TestStatusText(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     Language
+		fileSize int64
+		page     int
+		zoom     float64
+		want     string
+	}{
+		{
+			name:     "english megabytes",
+			lang:     LangEnglish,
+			fileSize: 3355443,
+			page:     5,
+			zoom:     1.25,
+			want:     "document.pdf  |  PDF  |  Page 5 / 120  |  Zoom: 125%  |  Size: 3.2 MB",
+		},
+		{
+			name:     "chinese megabytes",
+			lang:     LangChinese,
+			fileSize: 3355443,
+			page:     5,
+			zoom:     1.25,
+			want:     "document.pdf  |  PDF  |  第 5 / 120 页  |  缩放: 125%  |  大小: 3.2 MB",
+		},
+		{
+			name:     "english kilobytes",
+			lang:     LangEnglish,
+			fileSize: 2048,
+			page:     1,
+			zoom:     0.5,
+			want:     "document.pdf  |  PDF  |  Page 1 / 120  |  Zoom: 50%  |  Size: 2.0 KB",
+		},
+		{
+			name:     "chinese bytes",
+			lang:     LangChinese,
+			fileSize: 512,
+			page:     120,
+			zoom:     3.0,
+			want:     "document.pdf  |  PDF  |  第 120 / 120 页  |  缩放: 300%  |  大小: 512 B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newFakeEngine(120)
+			engine.fileSize = tt.fileSize
+			c := newTestController(t, engine)
+			c.GoToPage(tt.page)
+			c.SetZoom(tt.zoom)
+
+			if got := c.GetStatusText(GetTranslations(tt.lang)); got != tt.want {
+				t.Errorf("GetStatusText() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusTextFormatAndSizeError(t *testing.T) {
+	engine := newFakeEngine(2)
+	engine.name = "book.epub"
+	engine.format = FormatEPUB
+	engine.sizeErr = errors.New("stat failed")
+	c := newTestController(t, engine)
+
+	want := "book.epub  |  EPUB  |  Page 1 / 2  |  Zoom: 100%  |  Size: "
+	if got := c.GetStatusText(GetTranslations(LangEnglish)); got != want {
+		t.Errorf("GetStatusText() = %q, want %q", got, want)
+	}
+}
+
+func TestStatusTextWithoutDocument(t *testing.T) {
+	c := NewController()
+	for _, lang := range []Language{LangEnglish, LangChinese} {
+		tr := GetTranslations(lang)
+		if got := c.GetStatusText(tr); got != tr.StatusNoDocument {
+			t.Errorf("%s: GetStatusText() = %q, want %q", lang, got, tr.StatusNoDocument)
+		}
+	}
+}
+
+func TestSetLayout(t *testing.T) {
+	engine := newFakeEngine(100)
+	engine.format = FormatEPUB
+	engine.relayoutPages = 200
+	c := newTestController(t, engine)
+	c.GoToPage(51)
+
+	opts := LayoutOptions{PageWidth: 300, PageHeight: 400, FontSize: 18}
+	if err := c.SetLayout(opts); err != nil {
+		t.Fatalf("SetLayout() = %v", err)
+	}
+	if engine.layout != opts {
+		t.Errorf("engine layout = %+v, want %+v", engine.layout, opts)
+	}
+	// 页数翻倍后仍停留在文档的相同位置
+	if got := c.GetCurrentPage(); got != 101 {
+		t.Errorf("GetCurrentPage() after re-layout = %d, want 101", got)
+	}
+}
+
+func TestSetLayoutUnsupported(t *testing.T) {
+	c := newTestController(t, newFakeEngine(10))
+	c.GoToPage(4)
+
+	if err := c.SetLayout(defaultLayout); !errors.Is(err, ErrLayoutUnsupported) {
+		t.Errorf("SetLayout() on a PDF = %v, want ErrLayoutUnsupported", err)
+	}
+	if got := c.GetCurrentPage(); got != 4 {
+		t.Errorf("GetCurrentPage() = %d, want 4", got)
+	}
+}
+
+func TestRenderCurrentPage(t *testing.T) {
+	engine := newFakeEngine(10)
+	c := newTestController(t, engine)
+	c.SetPrefetch(0, true)
+	c.GoToPage(3)
+	c.SetZoom(2.0)
+
+	type result struct {
+		img image.Image
+		err error
+	}
+	done := make(chan result, 1)
+	c.RenderCurrentPage(context.Background(), func(img image.Image, err error) {
+		done <- result{img, err}
+	})
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("RenderCurrentPage() error = %v", r.err)
+		}
+		// 300 DPI 下 A4 为 2479 × 3508 像素
+		if got := r.img.Bounds().Size(); got != image.Pt(2479, 3508) {
+			t.Errorf("rendered image size = %v, want (2479,3508)", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RenderCurrentPage() did not call done")
+	}
+
+	if got := engine.renderedPages(); !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("rendered pages = %v, want [3]", got)
+	}
+}
+
+func TestRenderSpread(t *testing.T) {
+	engine := newFakeEngine(10)
+	c := newTestController(t, engine)
+	c.SetPrefetch(0, true)
+	c.SetSpreadMode(SpreadFacing)
+	c.GoToPage(4)
+
+	done := make(chan image.Image, 1)
+	c.RenderCurrentPage(context.Background(), func(img image.Image, err error) {
+		if err != nil {
+			t.Errorf("RenderCurrentPage() error = %v", err)
+		}
+		done <- img
+	})
+
+	select {
+	case img := <-done:
+		if img == nil {
+			t.Fatal("RenderCurrentPage() returned no image")
+		}
+		// 150 DPI 下 A4 为 1239 × 1754 像素，右页从 (595+12) 点即 1265 像素处开始
+		if got := img.Bounds().Size(); got != image.Pt(1265+1239, 1754) {
+			t.Errorf("rendered spread size = %v, want (2504,1754)", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RenderCurrentPage() did not call done")
+	}
+
+	if got := engine.renderedPages(); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("rendered pages = %v, want [3 4]", got)
+	}
+
+	placements := c.GetPagePlacements(c.GetVisiblePages())
+	want := map[int]pagePlacement{
+		3: {X: 0, Size: PageSize{Page: 3, Width: 595, Height: 842}},
+		4: {X: 595 + spreadGap, Size: PageSize{Page: 4, Width: 595, Height: 842}},
+	}
+	if !reflect.DeepEqual(placements, want) {
+		t.Errorf("GetPagePlacements() = %v, want %v", placements, want)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	c := newTestController(t, newFakeEngine(5))
+	c.GoToPage(2)
+	c.RotatePages(90)
+
+	if got := c.GetRotation(2); got != 90 {
+		t.Errorf("GetRotation(2) = %d, want 90", got)
+	}
+	if got := c.GetRotation(3); got != 0 {
+		t.Errorf("GetRotation(3) = %d, want 0", got)
+	}
+
+	// 视图旋转叠加在页面旋转之上
+	c.RotateView(-90)
+	if got := c.GetRotation(2); got != 0 {
+		t.Errorf("GetRotation(2) after rotating the view back = %d, want 0", got)
+	}
+	if got := c.GetRotation(3); got != 270 {
+		t.Errorf("GetRotation(3) = %d, want 270", got)
+	}
+
+	// 翻页后页面旋转仍然保留
+	c.NextPage()
+	c.PrevPage()
+	c.RotateView(90)
+	if got := c.GetRotation(2); got != 90 {
+		t.Errorf("GetRotation(2) after navigating = %d, want 90", got)
+	}
+
+	tr := GetTranslations(LangEnglish)
+	want := "document.pdf  |  PDF  |  Page 2 / 5  |  Zoom: 100%  |  Size: 3.2 MB  |  Rotation: 90°"
+	if got := c.GetStatusText(tr); got != want {
+		t.Errorf("GetStatusText() =\n  %q\nwant\n  %q", got, want)
+	}
+
+	c.setEngine(newFakeEngine(5))
+	if got := c.GetRotation(2); got != 0 {
+		t.Errorf("GetRotation(2) after opening another document = %d, want 0", got)
+	}
+}
+
+func TestRotatedSpreadPlacement(t *testing.T) {
+	engine := newFakeEngine(4)
+	c := newTestController(t, engine)
+	c.SetPrefetch(0, true)
+	c.SetSpreadMode(SpreadFacing)
+	c.RotatePages(90) // 第 1、2 页横置
+
+	done := make(chan image.Image, 1)
+	c.RenderCurrentPage(context.Background(), func(img image.Image, err error) {
+		if err != nil {
+			t.Errorf("RenderCurrentPage() error = %v", err)
+		}
+		done <- img
+	})
+
+	select {
+	case img := <-done:
+		if img == nil {
+			t.Fatal("RenderCurrentPage() returned no image")
+		}
+		// 150 DPI 下旋转后每页为 1754 × 1239 像素，右页从 (842+12) 点即 1779 像素处开始
+		if got := img.Bounds().Size(); got != image.Pt(1779+1754, 1239) {
+			t.Errorf("rotated spread size = %v, want (3533,1239)", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RenderCurrentPage() did not call done")
+	}
+
+	placement := c.GetPagePlacements(c.GetVisiblePages())[2]
+	if placement.X != 842+spreadGap || placement.Rotation != 90 {
+		t.Errorf("placement of page 2 = %+v, want X=%d Rotation=90", placement, 842+spreadGap)
+	}
+}
+
+func TestControllerDelegatesToEngine(t *testing.T) {
+	c := newTestController(t, newFakeEngine(4))
+	c.GoToPage(2)
+
+	text, err := c.GetCurrentPageText()
+	if err != nil || text != "page 2" {
+		t.Errorf("GetCurrentPageText() = %q, %v; want \"page 2\", nil", text, err)
+	}
+	if outline, err := c.GetOutline(); err != nil || len(outline) != 1 {
+		t.Errorf("GetOutline() = %v, %v; want one item", outline, err)
+	}
+	if _, err := c.GetPageLinks(5); err == nil {
+		t.Error("GetPageLinks(5) on a 4-page document should fail")
+	}
+	if got := c.GetFormat(); got != FormatPDF {
+		t.Errorf("GetFormat() = %q, want %q", got, FormatPDF)
+	}
+	if c.Engine() != nil {
+		t.Error("Engine() should be nil for a non-MuPDF engine")
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "image"
+
+// DocumentEngine 控制器和渲染协程依赖的文档操作
+// PDFEngine 是基于 MuPDF 的实现，测试中使用内存中的假实现
+type DocumentEngine interface {
+	GetPageCount() int
+	GetFileName() string
+	GetFileSize() (int64, error)
+	GetFormat() DocumentFormat
+	GetPageSize(pageNum int) (PageSize, error)
+
+	RenderPageWithOptions(pageNum int, dpi int, opts RenderOptions) (image.Image, error)
+	RenderPageUncached(pageNum int, dpi int, opts RenderOptions) (image.Image, error)
+	PrefetchPage(pageNum int, dpi int, opts RenderOptions) error
+
+	GetPageText(pageNum int) (string, error)
+	GetOutline() ([]OutlineItem, error)
+	GetPageLinks(pageNum int) ([]PageLink, error)
+
+	SetLayout(opts LayoutOptions) error
+	Close() error
+}
+
+var _ DocumentEngine = (*PDFEngine)(nil)
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PageSize 页面尺寸（单位：点，1/72 英寸）
+type PageSize struct {
+	Page   int     `json:"page"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// DocumentInfo 文档属性
+type DocumentInfo struct {
+	Name         string         `json:"name"`
+	Path         string         `json:"path,omitempty"`
+	Format       DocumentFormat `json:"format"`
+	PDFVersion   string         `json:"pdfVersion,omitempty"`
+	Title        string         `json:"title,omitempty"`
+	Author       string         `json:"author,omitempty"`
+	Subject      string         `json:"subject,omitempty"`
+	Keywords     string         `json:"keywords,omitempty"`
+	Creator      string         `json:"creator,omitempty"`
+	Producer     string         `json:"producer,omitempty"`
+	CreationDate string         `json:"creationDate,omitempty"` // RFC 3339，无法解析时保留原始值
+	ModDate      string         `json:"modDate,omitempty"`
+	Encrypted    bool           `json:"encrypted"`
+	Encryption   string         `json:"encryption,omitempty"` // MuPDF 报告的加密方式
+	PageCount    int            `json:"pageCount"`
+	PageSizes    []PageSize     `json:"pageSizes"`
+	OutlineDepth int            `json:"outlineDepth"` // 目录最大层级，无目录时为 0
+	FileSize     int64          `json:"fileSize"`
+	SHA256       string         `json:"sha256"`
+}
+
+// GetMetadata 读取 MuPDF 报告的文档元数据
+func (e *PDFEngine) GetMetadata() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.document == nil {
+		return nil
+	}
+
+	// go-fitz 把每个值读入 256 字节的缓冲区，未用到的部分以 NUL 填充
+	meta := e.document.Metadata()
+	for key, value := range meta {
+		if i := strings.IndexByte(value, 0); i >= 0 {
+			meta[key] = value[:i]
+		}
+	}
+	return meta
+}
+
+// pdfInfoString 通过 pdfcpu 读取文档信息字典中的字符串，与链接索引共用解析结果
+func (e *PDFEngine) pdfInfoString(key string) string {
+	e.linksMu.Lock()
+	defer e.linksMu.Unlock()
+
+	idx, err := e.linkIndexLocked()
+	if err != nil || idx.ctx.Info == nil {
+		return ""
+	}
+	info, err := idx.ctx.DereferenceDict(*idx.ctx.Info)
+	if err != nil || info == nil {
+		return ""
+	}
+	return idx.text(info[key])
+}
+
+// GetPageSize 返回指定页面的尺寸（单位：点）
+func (e *PDFEngine) GetPageSize(pageNum int) (PageSize, error) {
+	e.mu.Lock()
+	if err := e.checkPageLocked(pageNum); err != nil {
+		e.mu.Unlock()
+		return PageSize{}, err
+	}
+	bound, err := e.document.Bound(pageNum - 1)
+	e.mu.Unlock()
+	if err != nil {
+		return PageSize{}, fmt.Errorf("读取页面尺寸失败: %w", err)
+	}
+
+	return PageSize{
+		Page:   pageNum,
+		Width:  float64(bound.Dx()),
+		Height: float64(bound.Dy()),
+	}, nil
+}
+
+// GetInfo 汇总文档属性（计算 SHA-256 需要读取整个文件）
+func (e *PDFEngine) GetInfo() (*DocumentInfo, error) {
+	meta := e.GetMetadata()
+	pageCount := e.GetPageCount()
+
+	info := &DocumentInfo{
+		Name:         e.name,
+		Path:         e.filePath,
+		Format:       e.format,
+		Title:        meta["title"],
+		Author:       meta["author"],
+		Subject:      meta["subject"],
+		Keywords:     meta["keywords"],
+		Creator:      meta["creator"],
+		Producer:     meta["producer"],
+		CreationDate: normalizePDFDate(meta["creationDate"]),
+		Encrypted:    e.encrypted,
+		PageCount:    pageCount,
+		PageSizes:    make([]PageSize, 0, pageCount),
+	}
+
+	if e.format == FormatPDF {
+		info.PDFVersion = strings.TrimSpace(strings.TrimPrefix(meta["format"], "PDF"))
+		// go-fitz 按 info:modDate 查询修改日期，与 PDF 的键名 ModDate 大小写不符，总是读不到
+		info.ModDate = normalizePDFDate(e.pdfInfoString("ModDate"))
+	}
+	if enc := meta["encryption"]; enc != "" && enc != "None" {
+		info.Encryption = enc
+	}
+
+	for page := 1; page <= pageCount; page++ {
+		size, err := e.GetPageSize(page)
+		if err != nil {
+			return nil, err
+		}
+		info.PageSizes = append(info.PageSizes, size)
+	}
+
+	// 目录读取失败时按无目录处理
+	if outline, err := e.GetOutline(); err == nil {
+		for _, item := range outline {
+			if item.Level > info.OutlineDepth {
+				info.OutlineDepth = item.Level
+			}
+		}
+	}
+
+	size, err := e.GetFileSize()
+	if err != nil {
+		return nil, fmt.Errorf("读取文件大小失败: %w", err)
+	}
+	info.FileSize = size
+
+	hash := sha256.New()
+	if err := e.SaveTo(hash); err != nil {
+		return nil, fmt.Errorf("计算 SHA-256 失败: %w", err)
+	}
+	info.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	return info, nil
+}
+
+// PageSizeSummary 相同尺寸的连续页面
+type PageSizeSummary struct {
+	Width, Height float64
+	FirstPage     int
+	LastPage      int
+}
+
+// SummarizePageSizes 合并相同尺寸的连续页面，便于显示
+func (info *DocumentInfo) SummarizePageSizes() []PageSizeSummary {
+	var out []PageSizeSummary
+	for _, size := range info.PageSizes {
+		if n := len(out); n > 0 && out[n-1].Width == size.Width && out[n-1].Height == size.Height {
+			out[n-1].LastPage = size.Page
+			continue
+		}
+		out = append(out, PageSizeSummary{
+			Width:     size.Width,
+			Height:    size.Height,
+			FirstPage: size.Page,
+			LastPage:  size.Page,
+		})
+	}
+	return out
+}
+
+// infoField 一项文档属性的名称和显示值
+type infoField struct {
+	Label     string
+	Value     string
+	Monospace bool
+}
+
+// Fields 按显示顺序返回非空的属性，属性对话框和 info 子命令共用
+func (info *DocumentInfo) Fields(tr *Translations) []infoField {
+	var fields []infoField
+	add := func(label, value string) {
+		if value != "" {
+			fields = append(fields, infoField{Label: label, Value: value})
+		}
+	}
+
+	add(tr.PropFileName, info.Name)
+	add(tr.PropLocation, info.Path)
+	add(tr.PropTitle, info.Title)
+	add(tr.PropAuthor, info.Author)
+	add(tr.PropSubject, info.Subject)
+	add(tr.PropKeywords, info.Keywords)
+	add(tr.PropCreator, info.Creator)
+	add(tr.PropProducer, info.Producer)
+	add(tr.PropCreated, displayDate(info.CreationDate))
+	add(tr.PropModified, displayDate(info.ModDate))
+	add(tr.PropFormat, string(info.Format))
+	add(tr.PropPDFVersion, info.PDFVersion)
+	add(tr.PropEncryption, info.EncryptionText(tr))
+	add(tr.PropPageCount, fmt.Sprintf("%d", info.PageCount))
+	add(tr.PropPageSizes, info.PageSizesText(tr))
+	add(tr.PropOutlineDepth, fmt.Sprintf("%d", info.OutlineDepth))
+	add(tr.PropFileSize, fmt.Sprintf("%s (%d B)", formatFileSize(info.FileSize), info.FileSize))
+	fields = append(fields, infoField{Label: tr.PropSHA256, Value: info.SHA256, Monospace: true})
+	return fields
+}
+
+// EncryptionText 返回加密状态的显示文本
+func (info *DocumentInfo) EncryptionText(tr *Translations) string {
+	if !info.Encrypted {
+		return tr.PropNotEncrypted
+	}
+	if info.Encryption != "" {
+		return fmt.Sprintf("%s (%s)", tr.PropEncrypted, info.Encryption)
+	}
+	return tr.PropEncrypted
+}
+
+// PageSizesText 返回页面尺寸的显示文本，相同尺寸的连续页面合并为一行
+func (info *DocumentInfo) PageSizesText(tr *Translations) string {
+	summaries := info.SummarizePageSizes()
+
+	lines := make([]string, 0, len(summaries))
+	for _, s := range summaries {
+		line := fmt.Sprintf("%.0f × %.0f pt (%.0f × %.0f mm)",
+			s.Width, s.Height, s.Width*25.4/72, s.Height*25.4/72)
+
+		// 所有页面尺寸相同时不显示页码范围
+		if len(summaries) > 1 {
+			pages := fmt.Sprintf(tr.PropSinglePage, s.FirstPage)
+			if s.LastPage > s.FirstPage {
+				pages = fmt.Sprintf(tr.PropPageRange, s.FirstPage, s.LastPage)
+			}
+			line += ", " + pages
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// displayDate 将 RFC 3339 日期格式化为便于阅读的形式，无法解析时原样返回
+func displayDate(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Format("2006-01-02 15:04:05 -07:00")
+}
+
+// formatFileSize 将字节数格式化为 B/KB/MB
+func formatFileSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	} else if size < 1024*1024 {
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
+
+// normalizePDFDate 将 PDF 日期转换为 RFC 3339，无法解析时原样返回
+func normalizePDFDate(s string) string {
+	if t, ok := parsePDFDate(s); ok {
+		return t.Format(time.RFC3339)
+	}
+	return s
+}
+
+// parsePDFDate 解析 PDF 日期字符串，格式为 D:YYYYMMDDHHmmSSOHH'mm'，除年份外均可省略
+func parsePDFDate(s string) (time.Time, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	if len(s) < 4 {
+		return time.Time{}, false
+	}
+
+	// 日期部分：年 月 日 时 分 秒
+	fields := []int{0, 1, 1, 0, 0, 0}
+	widths := []int{4, 2, 2, 2, 2, 2}
+	pos := 0
+	for i, w := range widths {
+		if pos+w > len(s) || !isDigits(s[pos:pos+w]) {
+			break
+		}
+		fields[i], _ = strconv.Atoi(s[pos : pos+w])
+		pos += w
+	}
+	if pos < 4 {
+		return time.Time{}, false
+	}
+
+	// 时区部分：Z、+HH'mm' 或 -HH'mm'，缺省为 UTC
+	loc := time.UTC
+	if rest := s[pos:]; rest != "" && (rest[0] == '+' || rest[0] == '-') {
+		digits := strings.NewReplacer("'", "", ":", "").Replace(rest[1:])
+		if len(digits) >= 2 && isDigits(digits[:2]) {
+			hours, _ := strconv.Atoi(digits[:2])
+			minutes := 0
+			if len(digits) >= 4 && isDigits(digits[2:4]) {
+				minutes, _ = strconv.Atoi(digits[2:4])
+			}
+			offset := hours*3600 + minutes*60
+			if rest[0] == '-' {
+				offset = -offset
+			}
+			loc = time.FixedZone("", offset)
+		}
+	}
+
+	t := time.Date(fields[0], time.Month(fields[1]), fields[2], fields[3], fields[4], fields[5], 0, loc)
+	return t, true
+}
+
+// isDigits 判断字符串是否全为数字
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
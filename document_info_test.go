@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildTestPDF 生成一页的最小 PDF，info 为文档信息字典的内容
+func buildTestPDF(info string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 300] >>",
+		"<< " + info + " >>",
+	}
+
+	var buf strings.Builder
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	return []byte(buf.String())
+}
+
+func TestGetInfoMetadata(t *testing.T) {
+	data := buildTestPDF("/Title (Annual Report) /Author (Zhang San) /CreationDate (D:20240102030405Z) /ModDate (D:20240607080910+08'00')")
+	engine, err := NewPDFEngineFromBytes("report.pdf", data, "")
+	if err != nil {
+		t.Fatalf("NewPDFEngineFromBytes() = %v", err)
+	}
+	defer engine.Close()
+
+	for key, value := range engine.GetMetadata() {
+		if strings.ContainsRune(value, 0) {
+			t.Errorf("GetMetadata()[%q] = %q contains NUL", key, value)
+		}
+	}
+
+	info, err := engine.GetInfo()
+	if err != nil {
+		t.Fatalf("GetInfo() = %v", err)
+	}
+	if info.Title != "Annual Report" || info.Author != "Zhang San" {
+		t.Errorf("GetInfo() title, author = %q, %q; want \"Annual Report\", \"Zhang San\"", info.Title, info.Author)
+	}
+	if info.CreationDate != "2024-01-02T03:04:05Z" {
+		t.Errorf("GetInfo().CreationDate = %q, want 2024-01-02T03:04:05Z", info.CreationDate)
+	}
+	if info.ModDate != "2024-06-07T08:09:10+08:00" {
+		t.Errorf("GetInfo().ModDate = %q, want 2024-06-07T08:09:10+08:00", info.ModDate)
+	}
+}
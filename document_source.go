@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// stdinArg 表示从标准输入读取文档的命令行参数
+const stdinArg = "-"
+
+// documentSource 文档来源：本地文件或内存数据
+type documentSource struct {
+	path string // 本地文件路径
+	name string // 显示名称
+	data []byte // 内存数据（标准输入或非文件 URI）
+}
+
+// fileSource 本地文件来源
+func fileSource(path string) documentSource {
+	return documentSource{path: path, name: filepath.Base(path)}
+}
+
+// stdinSource 读取标准输入作为文档来源
+func stdinSource() (documentSource, error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return documentSource{}, fmt.Errorf("读取标准输入失败: %w", err)
+	}
+	return documentSource{name: "stdin", data: data}, nil
+}
+
+// readerSource 从 Fyne 存储读取器创建来源：file:// 按路径打开，其他 URI 读入内存
+func readerSource(reader fyne.URIReadCloser) (documentSource, error) {
+	uri := reader.URI()
+	if uri.Scheme() == "file" {
+		return fileSource(uri.Path()), nil
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return documentSource{}, fmt.Errorf("读取 %s 失败: %w", uri.String(), err)
+	}
+	return documentSource{name: uri.Name(), data: data}, nil
+}
+
+// uriSource 打开任意 Fyne 存储 URI
+func uriSource(uri fyne.URI) (documentSource, error) {
+	reader, err := storage.Reader(uri)
+	if err != nil {
+		return documentSource{}, fmt.Errorf("无法打开 %s: %w", uri.String(), err)
+	}
+	defer reader.Close()
+
+	return readerSource(reader)
+}
+
+// argSource 解析命令行参数：- 表示标准输入，带 scheme 的参数按 URI 打开，其余按文件路径打开
+func argSource(arg string) (documentSource, error) {
+	switch {
+	case arg == stdinArg:
+		return stdinSource()
+	case strings.Contains(arg, "://"):
+		uri, err := storage.ParseURI(arg)
+		if err != nil {
+			return documentSource{}, fmt.Errorf("无效的 URI %s: %w", arg, err)
+		}
+		return uriSource(uri)
+	default:
+		return fileSource(arg), nil
+	}
+}
+
+// openEngine 直接为此来源创建引擎（命令行模式不需要控制器）
+func (src documentSource) openEngine(password string) (*PDFEngine, error) {
+	if src.data != nil {
+		return NewPDFEngineFromBytes(src.name, src.data, password)
+	}
+	return NewPDFEngineWithPassword(src.path, password)
+}
+
+// open 使用控制器打开此来源
+func (src documentSource) open(c *Controller, password string) error {
+	if src.data != nil {
+		return c.OpenPDFData(src.name, src.data, password)
+	}
+	return c.OpenPDFWithPassword(src.path, password)
+}
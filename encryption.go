@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gen2brain/go-fitz"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+var (
+	// ErrPasswordRequired 文档已加密，需要密码
+	ErrPasswordRequired = errors.New("文档已加密，需要密码")
+	// ErrWrongPassword 密码错误
+	ErrWrongPassword = errors.New("密码错误")
+	// ErrCorruptFile 文件损坏或不是有效的文档
+	ErrCorruptFile = errors.New("无法打开 PDF，文件可能已损坏")
+)
+
+// unlockDocument 处理 MuPDF 的打开结果：文档加密时用密码解密，其他错误归类为文件损坏
+// raw 返回原始文档数据，仅在需要解密时调用
+func unlockDocument(doc *fitz.Document, openErr error, password string, raw func() (io.ReadSeeker, error)) (*fitz.Document, bool, error) {
+	// 打开失败时 go-fitz 仍会返回已创建 MuPDF 上下文的文档，不再使用，先关闭
+	if openErr != nil {
+		closeDocument(doc)
+	}
+
+	switch {
+	case errors.Is(openErr, fitz.ErrNeedsPassword):
+		if password == "" {
+			return nil, true, ErrPasswordRequired
+		}
+		rs, err := raw()
+		if err != nil {
+			return nil, true, err
+		}
+		doc, err := openEncrypted(rs, password)
+		return doc, true, err
+	case openErr != nil:
+		return nil, false, fmt.Errorf("%w: %v", ErrCorruptFile, openErr)
+	}
+	return doc, false, nil
+}
+
+// openEncrypted 用密码在内存中解密文档后交给 MuPDF 打开
+// go-fitz 能识别加密文档，但没有提供验证密码的接口
+func openEncrypted(rs io.ReadSeeker, password string) (*fitz.Document, error) {
+	var buf bytes.Buffer
+	if err := api.Decrypt(rs, &buf, pdfcpuConfig(password)); err != nil {
+		if errors.Is(err, pdfcpu.ErrWrongPassword) {
+			return nil, ErrWrongPassword
+		}
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+
+	doc, err := fitz.NewFromMemory(buf.Bytes())
+	if err != nil {
+		closeDocument(doc)
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+	return doc, nil
+}
+
+// closeDocument 关闭打开失败时 go-fitz 返回的文档（可能为 nil）
+func closeDocument(doc *fitz.Document) {
+	if doc != nil {
+		doc.Close()
+	}
+}
+
+// pdfcpuConfig 返回带密码的 pdfcpu 配置（同一密码同时作为用户密码和所有者密码尝试）
+func pdfcpuConfig(password string) *model.Configuration {
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = password
+	conf.OwnerPW = password
+	return conf
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenCorruptDocument(t *testing.T) {
+	// 打开失败后关闭 go-fitz 返回的半初始化文档，不能崩溃
+	for name, data := range map[string][]byte{
+		"garbage":   []byte("not a document at all"),
+		"truncated": []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog"),
+	} {
+		if _, err := NewPDFEngineFromBytes(name+".pdf", data, ""); !errors.Is(err, ErrCorruptFile) {
+			t.Errorf("NewPDFEngineFromBytes(%s) = %v, want ErrCorruptFile", name, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ImageFormat 页面导出格式
+type ImageFormat string
+
+const (
+	ImagePNG  ImageFormat = "png"
+	ImageJPEG ImageFormat = "jpeg"
+	ImageSVG  ImageFormat = "svg"
+)
+
+// imageFormats 导出对话框和命令行可选的格式
+var imageFormats = []ImageFormat{ImagePNG, ImageJPEG, ImageSVG}
+
+// parseImageFormat 解析导出格式名称（不区分大小写，接受 jpg）
+func parseImageFormat(name string) (ImageFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "png":
+		return ImagePNG, nil
+	case "jpeg", "jpg":
+		return ImageJPEG, nil
+	case "svg":
+		return ImageSVG, nil
+	}
+	return "", fmt.Errorf("不支持的导出格式: %s", name)
+}
+
+// Extension 返回导出文件的扩展名
+func (f ImageFormat) Extension() string {
+	if f == ImageJPEG {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+// ExportOptions 页面导出选项
+type ExportOptions struct {
+	Format  ImageFormat
+	DPI     int // PNG/JPEG 的渲染分辨率，SVG 忽略
+	Quality int // JPEG 质量（1-100）
+}
+
+// 导出默认值和 DPI 取值范围
+const (
+	defaultJPEGQuality = 90
+	defaultExportDPI   = 150
+	minExportDPI       = 36
+	maxExportDPI       = 1200
+)
+
+// GetPageSVG 将指定页面转换为 SVG
+func (e *PDFEngine) GetPageSVG(pageNum int) (string, error) {
+	e.mu.Lock()
+	if err := e.checkPageLocked(pageNum); err != nil {
+		e.mu.Unlock()
+		return "", err
+	}
+	svg, err := e.document.SVG(pageNum - 1)
+	e.mu.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("转换 SVG 失败: %w", err)
+	}
+	return svg, nil
+}
+
+// ExportPage 按导出选项将指定页面写入 w
+func (e *PDFEngine) ExportPage(w io.Writer, pageNum int, opts ExportOptions) error {
+	if opts.Format == ImageSVG {
+		svg, err := e.GetPageSVG(pageNum)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, svg)
+		return err
+	}
+
+	img, err := e.RenderPage(pageNum, opts.DPI)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case ImagePNG:
+		return png.Encode(w, img)
+	case ImageJPEG:
+		quality := opts.Quality
+		if quality < 1 || quality > 100 {
+			quality = defaultJPEGQuality
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	}
+	return fmt.Errorf("不支持的导出格式: %s", opts.Format)
+}
+
+// exportFileName 生成导出文件名，例如 slides-p007.png（页码按总页数补零）
+func exportFileName(docName string, pageNum, pageCount int, format ImageFormat) string {
+	base := strings.TrimSuffix(docName, filepath.Ext(docName))
+	if base == "" {
+		base = "page"
+	}
+	width := len(fmt.Sprint(pageCount))
+	return fmt.Sprintf("%s-p%0*d%s", base, width, pageNum, format.Extension())
+}
+
+// exportPages 依次导出页面，create 根据文件名创建输出，progress 在每页完成后回调
+// ctx 取消时停止并返回已导出的页数
+func exportPages(ctx context.Context, e *PDFEngine, pages []int, opts ExportOptions,
+	create func(name string) (io.WriteCloser, error), progress func(done, total int)) (int, error) {
+
+	for i, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		name := exportFileName(e.GetFileName(), page, e.GetPageCount(), opts.Format)
+		w, err := create(name)
+		if err != nil {
+			return i, fmt.Errorf("创建 %s 失败: %w", name, err)
+		}
+
+		err = e.ExportPage(w, page, opts)
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return i, fmt.Errorf("导出第 %d 页失败: %w", page, err)
+		}
+
+		if progress != nil {
+			progress(i+1, len(pages))
+		}
+	}
+	return len(pages), nil
+}
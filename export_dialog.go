@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// onExportPages 导出页面为 PNG/JPEG/SVG 图片
+func (ui *ViewerUI) onExportPages() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		dialog.ShowInformation(ui.tr.DialogExportTitle, ui.tr.StatusNoDocument, ui.window)
+		return
+	}
+
+	engine := currentTab.controller.Engine()
+
+	rangeEntry := widget.NewEntry()
+	rangeEntry.SetPlaceHolder(ui.tr.HintPageRange)
+	rangeEntry.SetText(strconv.Itoa(currentTab.controller.GetCurrentPage()))
+
+	dpiEntry := widget.NewEntry()
+	dpiEntry.SetText(strconv.Itoa(defaultExportDPI))
+
+	qualityLabel := widget.NewLabel(strconv.Itoa(defaultJPEGQuality))
+	qualitySlider := widget.NewSlider(1, 100)
+	qualitySlider.SetValue(defaultJPEGQuality)
+	qualitySlider.OnChanged = func(v float64) {
+		qualityLabel.SetText(strconv.Itoa(int(v)))
+	}
+
+	formatNames := make([]string, len(imageFormats))
+	for i, f := range imageFormats {
+		formatNames[i] = strings.ToUpper(string(f))
+	}
+	formatSelect := widget.NewSelect(formatNames, func(name string) {
+		// 质量只对 JPEG 有效，DPI 对 SVG 无效
+		format, _ := parseImageFormat(name)
+		if format == ImageJPEG {
+			qualitySlider.Enable()
+		} else {
+			qualitySlider.Disable()
+		}
+		if format == ImageSVG {
+			dpiEntry.Disable()
+		} else {
+			dpiEntry.Enable()
+		}
+	})
+	formatSelect.SetSelected(formatNames[0])
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(ui.tr.LabelPageRange, rangeEntry),
+		widget.NewFormItem(ui.tr.LabelFormat, formatSelect),
+		widget.NewFormItem(ui.tr.LabelDPI, dpiEntry),
+		widget.NewFormItem(ui.tr.LabelJPEGQuality, container.NewBorder(nil, nil, nil, qualityLabel, qualitySlider)),
+	}
+
+	d := dialog.NewForm(ui.tr.DialogExportTitle, "OK", ui.tr.ButtonCancel, items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		pages, err := parsePageRange(rangeEntry.Text, engine.GetPageCount())
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+
+		format, _ := parseImageFormat(formatSelect.Selected)
+		opts := ExportOptions{Format: format, Quality: int(qualitySlider.Value), DPI: defaultExportDPI}
+		if format != ImageSVG {
+			dpi, err := strconv.Atoi(strings.TrimSpace(dpiEntry.Text))
+			if err != nil || dpi < minExportDPI || dpi > maxExportDPI {
+				dialog.ShowError(errors.New(ui.tr.MsgInvalidDPI), ui.window)
+				return
+			}
+			opts.DPI = dpi
+		}
+
+		// 选择输出目录，每页写入一个文件
+		dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err != nil || dir == nil {
+				return
+			}
+			ui.runExport(engine, pages, opts, dir)
+		}, ui.window)
+	}, ui.window)
+	d.Resize(fyne.NewSize(420, d.MinSize().Height))
+	d.Show()
+}
+
+// runExport 在后台导出页面，显示进度条并允许取消
+func (ui *ViewerUI) runExport(engine *PDFEngine, pages []int, opts ExportOptions, dir fyne.ListableURI) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	progressBar := widget.NewProgressBar()
+	progressBar.Max = float64(len(pages))
+	progressLabel := widget.NewLabel(fmt.Sprintf(ui.tr.MsgExportProgress, 1, len(pages)))
+
+	// 关闭对话框（取消按钮或导出完成）都会取消上下文
+	progress := dialog.NewCustom(ui.tr.DialogExportTitle, ui.tr.ButtonCancel,
+		container.NewVBox(progressLabel, progressBar), ui.window)
+	progress.SetOnClosed(cancel)
+	progress.Resize(fyne.NewSize(360, progress.MinSize().Height))
+	progress.Show()
+
+	create := func(name string) (io.WriteCloser, error) {
+		uri, err := storage.Child(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		return storage.Writer(uri)
+	}
+
+	go func() {
+		done, err := exportPages(ctx, engine, pages, opts, create, func(done, total int) {
+			progressBar.SetValue(float64(done))
+			if done < total {
+				progressLabel.SetText(fmt.Sprintf(ui.tr.MsgExportProgress, done+1, total))
+			}
+		})
+		cancelled := ctx.Err() != nil
+		progress.Hide()
+
+		switch {
+		case cancelled:
+			dialog.ShowInformation(ui.tr.DialogExportTitle, fmt.Sprintf(ui.tr.MsgExportCancelled, done), ui.window)
+		case err != nil:
+			dialog.ShowError(fmt.Errorf(ui.tr.MsgExportFailed, err), ui.window)
+		default:
+			dialog.ShowInformation(ui.tr.DialogExportTitle, fmt.Sprintf(ui.tr.MsgExportDone, done, dir.Path()), ui.window)
+		}
+	}()
+}
+
+// onExportText 将整个文档或页码范围导出为纯文本、HTML 或 Markdown
+func (ui *ViewerUI) onExportText() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		dialog.ShowInformation(ui.tr.DialogExportTextTitle, ui.tr.StatusNoDocument, ui.window)
+		return
+	}
+
+	engine := currentTab.controller.Engine()
+
+	rangeEntry := widget.NewEntry()
+	rangeEntry.SetPlaceHolder(ui.tr.HintPageRange)
+	rangeEntry.SetText("1-")
+
+	formatNames := map[string]TextFormat{
+		ui.tr.TextFormatPlain:    TextPlain,
+		ui.tr.TextFormatHTML:     TextHTML,
+		ui.tr.TextFormatMarkdown: TextMarkdown,
+	}
+	formatSelect := widget.NewSelect([]string{ui.tr.TextFormatPlain, ui.tr.TextFormatHTML, ui.tr.TextFormatMarkdown}, nil)
+	formatSelect.SetSelected(ui.tr.TextFormatPlain)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(ui.tr.LabelPageRange, rangeEntry),
+		widget.NewFormItem(ui.tr.LabelFormat, formatSelect),
+	}
+
+	d := dialog.NewForm(ui.tr.DialogExportTextTitle, "OK", ui.tr.ButtonCancel, items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		pages, err := parsePageRange(rangeEntry.Text, engine.GetPageCount())
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		format := formatNames[formatSelect.Selected]
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+
+			// 提取文本可能较慢，在后台执行
+			go func() {
+				err := engine.ExportText(context.Background(), writer, pages, format)
+				if closeErr := writer.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					dialog.ShowError(fmt.Errorf(ui.tr.MsgExportFailed, err), ui.window)
+					return
+				}
+				dialog.ShowInformation(ui.tr.DialogExportTextTitle, ui.tr.MsgTextExported, ui.window)
+			}()
+		}, ui.window)
+
+		name := engine.GetFileName()
+		saveDialog.SetFileName(strings.TrimSuffix(name, filepath.Ext(name)) + format.Extension())
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+		saveDialog.Show()
+	}, ui.window)
+	d.Resize(fyne.NewSize(420, d.MinSize().Height))
+	d.Show()
+}
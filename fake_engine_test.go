@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"sync"
+)
+
+// fakeEngine 内存中的 DocumentEngine 实现，不依赖 MuPDF
+type fakeEngine struct {
+	name      string
+	pageCount int
+	fileSize  int64
+	sizeErr   error
+	format    DocumentFormat
+
+	// relayoutPages 不为 0 时，SetLayout 将页数改为该值
+	relayoutPages int
+
+	// started 和 release 不为 nil 时，每次渲染先把页码发到 started，再等待 release，用于在渲染期间提交新请求
+	started chan int
+	release chan struct{}
+
+	mu       sync.Mutex
+	rendered []int // 按顺序记录渲染过的页码
+	layout   LayoutOptions
+	closed   bool
+}
+
+// newFakeEngine 创建指定页数的假文档
+func newFakeEngine(pageCount int) *fakeEngine {
+	return &fakeEngine{
+		name:      "document.pdf",
+		pageCount: pageCount,
+		fileSize:  3355443, // 3.2 MB
+		format:    FormatPDF,
+	}
+}
+
+func (f *fakeEngine) checkPage(pageNum int) error {
+	if pageNum < 1 || pageNum > f.pageCount {
+		return fmt.Errorf("页码超出范围: %d (总页数: %d)", pageNum, f.pageCount)
+	}
+	return nil
+}
+
+func (f *fakeEngine) GetPageCount() int           { return f.pageCount }
+func (f *fakeEngine) GetFileName() string         { return f.name }
+func (f *fakeEngine) GetFormat() DocumentFormat   { return f.format }
+func (f *fakeEngine) GetFileSize() (int64, error) { return f.fileSize, f.sizeErr }
+
+// GetPageSize 所有页面均为 A4（595 × 842 点）
+func (f *fakeEngine) GetPageSize(pageNum int) (PageSize, error) {
+	if err := f.checkPage(pageNum); err != nil {
+		return PageSize{}, err
+	}
+	return PageSize{Page: pageNum, Width: 595, Height: 842}, nil
+}
+
+func (f *fakeEngine) RenderPageWithOptions(pageNum int, dpi int, opts RenderOptions) (image.Image, error) {
+	if err := f.checkPage(pageNum); err != nil {
+		return nil, err
+	}
+	if f.started != nil {
+		f.started <- pageNum
+		<-f.release
+	}
+
+	f.mu.Lock()
+	f.rendered = append(f.rendered, pageNum)
+	f.mu.Unlock()
+
+	// 按 A4 比例生成空白图像，尺寸随 DPI 和旋转变化
+	w, h := 595*dpi/72, 842*dpi/72
+	if r := normalizeRotation(opts.Rotation); r == 90 || r == 270 {
+		w, h = h, w
+	}
+	return image.NewRGBA(image.Rect(0, 0, w, h)), nil
+}
+
+func (f *fakeEngine) RenderPageUncached(pageNum int, dpi int, opts RenderOptions) (image.Image, error) {
+	return f.RenderPageWithOptions(pageNum, dpi, opts)
+}
+
+func (f *fakeEngine) PrefetchPage(pageNum int, dpi int, opts RenderOptions) error {
+	_, err := f.RenderPageWithOptions(pageNum, dpi, opts)
+	return err
+}
+
+func (f *fakeEngine) GetPageText(pageNum int) (string, error) {
+	if err := f.checkPage(pageNum); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("page %d", pageNum), nil
+}
+
+func (f *fakeEngine) GetOutline() ([]OutlineItem, error) {
+	return []OutlineItem{{Level: 1, Title: "Start", Page: 1}}, nil
+}
+
+func (f *fakeEngine) GetPageLinks(pageNum int) ([]PageLink, error) {
+	return nil, f.checkPage(pageNum)
+}
+
+func (f *fakeEngine) SetLayout(opts LayoutOptions) error {
+	if !f.format.Reflowable() {
+		return ErrLayoutUnsupported
+	}
+	f.layout = opts
+	if f.relayoutPages > 0 {
+		f.pageCount = f.relayoutPages
+	}
+	return nil
+}
+
+func (f *fakeEngine) Close() error {
+	f.closed = true
+	return nil
+}
+
+// renderedPages 返回渲染过的页码副本
+func (f *fakeEngine) renderedPages() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int(nil), f.rendered...)
+}
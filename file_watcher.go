@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay 文件最后一次变化后等待多久再重新加载，避免读到写了一半的文件
+const reloadDelay = 300 * time.Millisecond
+
+// fileWatcher 监视本地文件的改写，文件稳定后回调 onChange
+// 监视的是所在目录而不是文件本身：LaTeX 和很多编辑器先写临时文件再改名覆盖原文件，
+// 对原文件的监视会随旧文件一起失效
+type fileWatcher struct {
+	path     string
+	watcher  *fsnotify.Watcher
+	onChange func()
+
+	mu     sync.Mutex
+	timer  *time.Timer
+	closed bool
+	done   chan struct{}
+}
+
+// newFileWatcher 开始监视文件，onChange 在后台协程中调用
+func newFileWatcher(path string, onChange func()) (*fileWatcher, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("解析路径失败: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("创建文件监视失败: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(abs)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("监视 %s 失败: %w", filepath.Dir(abs), err)
+	}
+
+	fw := &fileWatcher{
+		path:     abs,
+		watcher:  watcher,
+		onChange: onChange,
+		done:     make(chan struct{}),
+	}
+	go fw.run()
+	return fw, nil
+}
+
+// run 处理目录事件，只关心被监视文件的写入和（改名覆盖产生的）创建
+func (fw *fileWatcher) run() {
+	defer close(fw.done)
+
+	for {
+		select {
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != fw.path {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				fw.schedule()
+			}
+		case _, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// schedule 每次变化都重新计时，写入停止 reloadDelay 后才触发
+func (fw *fileWatcher) schedule() {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed {
+		return
+	}
+
+	if fw.timer != nil {
+		fw.timer.Stop()
+	}
+	fw.timer = time.AfterFunc(reloadDelay, fw.fire)
+}
+
+// fire 文件仍存在时回调；文件被删除或改名移走时等待新文件出现
+func (fw *fileWatcher) fire() {
+	fw.mu.Lock()
+	closed := fw.closed
+	fw.mu.Unlock()
+	if closed {
+		return
+	}
+
+	if _, err := os.Stat(fw.path); err != nil {
+		return
+	}
+	fw.onChange()
+}
+
+// Close 停止监视，之后不再触发新的回调（不等待正在执行的回调）
+func (fw *fileWatcher) Close() error {
+	fw.mu.Lock()
+	fw.closed = true
+	if fw.timer != nil {
+		fw.timer.Stop()
+	}
+	fw.mu.Unlock()
+
+	err := fw.watcher.Close()
+	<-fw.done
+	return err
+}
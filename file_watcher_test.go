@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// watchTestFile 在临时目录中创建文件并开始监视，返回文件路径和回调通知
+func watchTestFile(t *testing.T) (string, *fileWatcher, chan struct{}) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "paper.pdf")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed := make(chan struct{}, 10)
+	fw, err := newFileWatcher(path, func() { changed <- struct{}{} })
+	if err != nil {
+		t.Fatalf("newFileWatcher() = %v", err)
+	}
+	t.Cleanup(func() { fw.Close() })
+	return path, fw, changed
+}
+
+// countCallbacks 统计 wait 时间内收到的回调次数
+func countCallbacks(changed chan struct{}, wait time.Duration) int {
+	n := 0
+	deadline := time.After(wait)
+	for {
+		select {
+		case <-changed:
+			n++
+		case <-deadline:
+			return n
+		}
+	}
+}
+
+func TestFileWatcherDebounce(t *testing.T) {
+	path, _, changed := watchTestFile(t)
+
+	// 间隔小于 reloadDelay 的多次写入只触发一次
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(path, []byte("v2"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(reloadDelay / 5)
+	}
+	if got := countCallbacks(changed, 3*reloadDelay); got != 1 {
+		t.Errorf("callbacks after a burst of writes = %d, want 1", got)
+	}
+}
+
+func TestFileWatcherRenameOver(t *testing.T) {
+	path, _, changed := watchTestFile(t)
+
+	// 先写临时文件再改名覆盖原文件（LaTeX 和很多编辑器的保存方式）
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	if got := countCallbacks(changed, 3*reloadDelay); got != 1 {
+		t.Errorf("callbacks after renaming over the file = %d, want 1", got)
+	}
+
+	// 改名覆盖后仍然监视新文件
+	if err := os.WriteFile(path, []byte("v3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := countCallbacks(changed, 3*reloadDelay); got != 1 {
+		t.Errorf("callbacks after writing the renamed file = %d, want 1", got)
+	}
+}
+
+func TestFileWatcherIgnoresOtherFiles(t *testing.T) {
+	path, _, changed := watchTestFile(t)
+
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "paper.log"), []byte("log"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := countCallbacks(changed, 2*reloadDelay); got != 0 {
+		t.Errorf("callbacks after writing another file = %d, want 0", got)
+	}
+}
+
+func TestFileWatcherNoCallbackAfterClose(t *testing.T) {
+	path, fw, changed := watchTestFile(t)
+
+	// 关闭时尚未到期的回调被取消，关闭后的写入也不再触发
+	if err := os.WriteFile(path, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(reloadDelay / 3)
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("v3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := countCallbacks(changed, 3*reloadDelay); got != 0 {
+		t.Errorf("callbacks after Close() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DocumentFormat MuPDF 支持的文档格式
+type DocumentFormat string
+
+const (
+	FormatPDF   DocumentFormat = "PDF"
+	FormatEPUB  DocumentFormat = "EPUB"
+	FormatXPS   DocumentFormat = "XPS"
+	FormatCBZ   DocumentFormat = "CBZ"
+	FormatCBR   DocumentFormat = "CBR"
+	FormatFB2   DocumentFormat = "FB2"
+	FormatMOBI  DocumentFormat = "MOBI"
+	FormatSVG   DocumentFormat = "SVG"
+	FormatImage DocumentFormat = "Image"
+)
+
+// MuPDF 排版可重排文档时的默认版面大小和字号（点）
+const (
+	defaultLayoutWidth    = 450
+	defaultLayoutHeight   = 600
+	defaultLayoutFontSize = 12
+	minLayoutPageSize     = 72 // 版面的最小边长
+)
+
+// ErrLayoutUnsupported 当前格式不支持重新排版
+var ErrLayoutUnsupported = errors.New("当前格式不支持重新排版")
+
+// formatExtensions 文件扩展名与格式的对应关系
+var formatExtensions = map[string]DocumentFormat{
+	".pdf":  FormatPDF,
+	".epub": FormatEPUB,
+	".xps":  FormatXPS,
+	".oxps": FormatXPS,
+	".cbz":  FormatCBZ,
+	".cbr":  FormatCBR,
+	".fb2":  FormatFB2,
+	".mobi": FormatMOBI,
+	".svg":  FormatSVG,
+	".png":  FormatImage,
+	".jpg":  FormatImage,
+	".jpeg": FormatImage,
+	".gif":  FormatImage,
+	".bmp":  FormatImage,
+	".tif":  FormatImage,
+	".tiff": FormatImage,
+	".pnm":  FormatImage,
+	".jxr":  FormatImage,
+	".jpx":  FormatImage,
+}
+
+// supportedExtensions 返回所有支持的扩展名（用于文件对话框过滤）
+func supportedExtensions() []string {
+	exts := make([]string, 0, len(formatExtensions))
+	for ext := range formatExtensions {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// detectFormat 根据 MuPDF 报告的格式和文件扩展名识别文档格式
+func detectFormat(name, mupdfFormat string) DocumentFormat {
+	switch upper := strings.ToUpper(mupdfFormat); {
+	case strings.HasPrefix(upper, "PDF"):
+		return FormatPDF
+	case strings.HasPrefix(upper, "EPUB"):
+		return FormatEPUB
+	case strings.HasPrefix(upper, "XPS"), strings.HasPrefix(upper, "OPENXPS"):
+		return FormatXPS
+	case strings.HasPrefix(upper, "CBZ"):
+		return FormatCBZ
+	case strings.HasPrefix(upper, "CBR"):
+		return FormatCBR
+	case strings.HasPrefix(upper, "FICTIONBOOK"), strings.HasPrefix(upper, "FB2"):
+		return FormatFB2
+	case strings.HasPrefix(upper, "MOBI"):
+		return FormatMOBI
+	case strings.HasPrefix(upper, "SVG"):
+		return FormatSVG
+	}
+
+	if format, ok := formatExtensions[strings.ToLower(filepath.Ext(name))]; ok {
+		return format
+	}
+	return FormatPDF
+}
+
+// Reflowable 是否为可重排格式（由 MuPDF 按版面大小和字号排版）
+func (f DocumentFormat) Reflowable() bool {
+	switch f {
+	case FormatEPUB, FormatFB2, FormatMOBI:
+		return true
+	}
+	return false
+}
+
+// LayoutOptions 可重排文档的排版设置
+type LayoutOptions struct {
+	PageWidth  float64 // 版面宽度（点）
+	PageHeight float64 // 版面高度（点）
+	FontSize   float64 // 正文字号（点）
+}
+
+// defaultLayout MuPDF 打开可重排文档时使用的排版设置
+var defaultLayout = LayoutOptions{
+	PageWidth:  defaultLayoutWidth,
+	PageHeight: defaultLayoutHeight,
+	FontSize:   defaultLayoutFontSize,
+}
+
+// validate 检查排版设置是否在 MuPDF 能处理的范围内
+func (opts LayoutOptions) validate() error {
+	if opts.PageWidth < minLayoutPageSize || opts.PageHeight < minLayoutPageSize {
+		return fmt.Errorf("版面过小: %.0f × %.0f pt", opts.PageWidth, opts.PageHeight)
+	}
+	if opts.FontSize <= 0 {
+		return fmt.Errorf("无效的字号: %v", opts.FontSize)
+	}
+	return nil
+}
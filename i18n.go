@@ -1,253 +1,578 @@
-package main
-
-// Language 语言类型
-type Language string
-
-const (
-	LangEnglish Language = "en"
-	LangChinese Language = "zh"
-)
-
-// Translations 翻译文本集合
-type Translations struct {
-	// Window
-	WindowTitle string
-
-	// Menu - File
-	MenuFile          string
-	MenuOpen          string
-	MenuNewTab        string
-	MenuSaveAs        string
-	MenuCloseTab      string
-	MenuExit          string
-
-	// Menu - View
-	MenuView          string
-	MenuFirstPage     string
-	MenuPrevPage      string
-	MenuNextPage      string
-	MenuLastPage      string
-	MenuZoomIn        string
-	MenuZoomOut       string
-	MenuActualSize    string
-
-	// Menu - Help
-	MenuHelp          string
-	MenuShortcuts     string
-	MenuAbout         string
-
-	// Menu - Language
-	MenuLanguage      string
-	MenuEnglish       string
-	MenuChinese       string
-
-	// Status
-	StatusNoDocument  string
-	StatusPage        string
-	StatusZoom        string
-	StatusSize        string
-
-	// Messages
-	MsgDoubleClickOpen    string
-	MsgLoading            string
-	MsgLoadFailed         string
-	MsgRenderFailed       string
-	MsgNoDocumentToSave   string
-	MsgSaveSuccess        string
-	MsgSaveFailed         string
-	MsgInvalidPage        string
-
-	// Dialogs
-	DialogShortcutsTitle  string
-	DialogShortcutsText   string
-	DialogAboutTitle      string
-	DialogAboutText       string
-
-	// Toolbar hints
-	HintOpen              string
-	HintSaveAs            string
-	HintCloseTab          string
-	HintFirstPage         string
-	HintPrevPage          string
-	HintNextPage          string
-	HintLastPage          string
-	HintPageEntry         string
-	HintZoomOut           string
-	HintZoomIn            string
-}
-
-// GetTranslations 获取指定语言的翻译
-func GetTranslations(lang Language) *Translations {
-	switch lang {
-	case LangChinese:
-		return getChineseTranslations()
-	default:
-		return getEnglishTranslations()
-	}
-}
-
-// getEnglishTranslations 英文翻译
-func getEnglishTranslations() *Translations {
-	return &Translations{
-		WindowTitle:       "PDF Reader",
-
-		MenuFile:          "File",
-		MenuOpen:          "Open...",
-		MenuNewTab:        "New Tab",
-		MenuSaveAs:        "Save As...",
-		MenuCloseTab:      "Close Tab",
-		MenuExit:          "Exit",
-
-		MenuView:          "View",
-		MenuFirstPage:     "First Page",
-		MenuPrevPage:      "Previous Page",
-		MenuNextPage:      "Next Page",
-		MenuLastPage:      "Last Page",
-		MenuZoomIn:        "Zoom In",
-		MenuZoomOut:       "Zoom Out",
-		MenuActualSize:    "Actual Size",
-
-		MenuHelp:          "Help",
-		MenuShortcuts:     "Shortcuts",
-		MenuAbout:         "About",
-
-		MenuLanguage:      "Language",
-		MenuEnglish:       "English",
-		MenuChinese:       "中文",
-
-		StatusNoDocument:  "No document open",
-		StatusPage:        "Page",
-		StatusZoom:        "Zoom",
-		StatusSize:        "Size",
-
-		MsgDoubleClickOpen:    "Double-click to open PDF file",
-		MsgLoading:            "Loading...",
-		MsgLoadFailed:         "Load failed: %v",
-		MsgRenderFailed:       "Render failed: %v",
-		MsgNoDocumentToSave:   "No document to save",
-		MsgSaveSuccess:        "File saved successfully",
-		MsgSaveFailed:         "Save failed: %v",
-		MsgInvalidPage:        "Invalid page number",
-
-		DialogShortcutsTitle: "Shortcuts",
-		DialogShortcutsText: `Keyboard Shortcuts:
-
-Navigation:
-  Left / PageUp      - Previous page
-  Right / PageDown   - Next page
-  Space              - Next page
-  Home               - First page
-  End                - Last page
-
-Other:
-  Ctrl+W             - Close current tab
-`,
-
-		DialogAboutTitle: "About",
-		DialogAboutText: `PDF Reader v1.2.4
-
-Built with Fyne + go-fitz
-A simple and efficient PDF reading tool
-
-GitHub: https://github.com/tools4daily/pdfviewer
-
-Open Source Licenses:
-- Fyne: BSD-3-Clause
-- go-fitz: AGPL-3.0
-`,
-
-		HintOpen:      "Open PDF file",
-		HintSaveAs:    "Save as",
-		HintCloseTab:  "Close current tab",
-		HintFirstPage: "First page",
-		HintPrevPage:  "Previous page",
-		HintNextPage:  "Next page",
-		HintLastPage:  "Last page",
-		HintPageEntry: "Page number",
-		HintZoomOut:   "Zoom out",
-		HintZoomIn:    "Zoom in",
-	}
-}
-
-// getChineseTranslations 中文翻译
-func getChineseTranslations() *Translations {
-	return &Translations{
-		WindowTitle:       "PDF 阅读器",
-
-		MenuFile:          "文件",
-		MenuOpen:          "打开...",
-		MenuNewTab:        "新建标签页",
-		MenuSaveAs:        "另存为...",
-		MenuCloseTab:      "关闭标签页",
-		MenuExit:          "退出",
-
-		MenuView:          "查看",
-		MenuFirstPage:     "首页",
-		MenuPrevPage:      "上一页",
-		MenuNextPage:      "下一页",
-		MenuLastPage:      "末页",
-		MenuZoomIn:        "放大",
-		MenuZoomOut:       "缩小",
-		MenuActualSize:    "实际大小",
-
-		MenuHelp:          "帮助",
-		MenuShortcuts:     "快捷键",
-		MenuAbout:         "关于",
-
-		MenuLanguage:      "语言",
-		MenuEnglish:       "English",
-		MenuChinese:       "中文",
-
-		StatusNoDocument:  "未打开文档",
-		StatusPage:        "第",
-		StatusZoom:        "缩放",
-		StatusSize:        "大小",
-
-		MsgDoubleClickOpen:    "双击打开 PDF 文件",
-		MsgLoading:            "正在加载...",
-		MsgLoadFailed:         "加载失败: %v",
-		MsgRenderFailed:       "渲染失败: %v",
-		MsgNoDocumentToSave:   "没有打开的文档可保存",
-		MsgSaveSuccess:        "文件已保存",
-		MsgSaveFailed:         "保存失败: %v",
-		MsgInvalidPage:        "无效的页码",
-
-		DialogShortcutsTitle: "快捷键",
-		DialogShortcutsText: `快捷键列表:
-
-导航:
-  左箭头 / PageUp    - 上一页
-  右箭头 / PageDown  - 下一页
-  空格键             - 下一页
-  Home              - 首页
-  End               - 末页
-
-其他:
-  Ctrl+W            - 关闭当前标签页
-`,
-
-		DialogAboutTitle: "关于",
-		DialogAboutText: `PDF 阅读器 v1.2.4
-
-基于 Fyne + go-fitz 开发
-简洁、高效的 PDF 阅读工具
-
-GitHub: https://github.com/tools4daily/pdfviewer
-
-开源许可:
-- Fyne: BSD-3-Clause
-- go-fitz: AGPL-3.0
-`,
-
-		HintOpen:      "打开 PDF 文件",
-		HintSaveAs:    "另存为",
-		HintCloseTab:  "关闭当前标签页",
-		HintFirstPage: "首页",
-		HintPrevPage:  "上一页",
-		HintNextPage:  "下一页",
-		HintLastPage:  "末页",
-		HintPageEntry: "页码",
-		HintZoomOut:   "缩小",
-		HintZoomIn:    "放大",
-	}
-}
+package main
+
+// Language 语言类型
+type Language string
+
+const (
+	LangEnglish Language = "en"
+	LangChinese Language = "zh"
+)
+
+// Translations 翻译文本集合
+type Translations struct {
+	// Window
+	WindowTitle string
+
+	// Menu - File
+	MenuFile        string
+	MenuOpen        string
+	MenuNewTab      string
+	MenuSaveAs      string
+	MenuProperties  string
+	MenuExportPages string
+	MenuExportText  string
+	MenuCloseTab    string
+	MenuExit        string
+
+	// Menu - View
+	MenuView          string
+	MenuFirstPage     string
+	MenuPrevPage      string
+	MenuNextPage      string
+	MenuLastPage      string
+	MenuZoomIn        string
+	MenuZoomOut       string
+	MenuActualSize    string
+	MenuFitWidth      string
+	MenuFitPage       string
+	MenuFitHeight     string
+	MenuRotatePageCW  string
+	MenuRotatePageCCW string
+	MenuRotateViewCW  string
+	MenuRotateViewCCW string
+	MenuCopyPageText  string
+	MenuFind          string
+	MenuShowOutline   string
+	MenuThumbnails    string
+	MenuContinuous    string
+	MenuSpread        string
+	MenuSpreadCover   string
+	MenuTextLayout    string
+
+	// Menu - Help
+	MenuHelp      string
+	MenuShortcuts string
+	MenuAbout     string
+
+	// Menu - Language
+	MenuLanguage string
+	MenuEnglish  string
+	MenuChinese  string
+
+	// Status
+	StatusNoDocument  string
+	StatusPage        string
+	StatusPages       string
+	StatusZoom        string
+	StatusSize        string
+	StatusRotation    string
+	StatusSearching   string
+	StatusSearchHit   string
+	StatusNoMatches   string
+	StatusLinkPage    string
+	StatusReloadError string
+
+	// Messages
+	MsgDoubleClickOpen   string
+	MsgLoading           string
+	MsgLoadFailed        string
+	MsgRenderFailed      string
+	MsgNoDocumentToSave  string
+	MsgSaveSuccess       string
+	MsgSaveFailed        string
+	MsgInvalidPage       string
+	MsgTextCopied        string
+	MsgNoPageText        string
+	MsgExtractTextFailed string
+	MsgSearchFailed      string
+	MsgNoOutline         string
+	MsgOpenLinkConfirm   string
+	MsgInvalidLink       string
+	MsgCorruptFile       string
+	MsgPasswordRequired  string
+	MsgWrongPassword     string
+	MsgPasswordCancelled string
+	MsgLayoutUnsupported string
+	MsgLayoutFailed      string
+	MsgPropertiesFailed  string
+	MsgJSONCopied        string
+	MsgExportProgress    string
+	MsgExportDone        string
+	MsgExportFailed      string
+	MsgExportCancelled   string
+	MsgInvalidDPI        string
+	MsgTextExported      string
+	MsgSyncTeXFailed     string
+	MsgSyncTeXSource     string
+	MsgEditorFailed      string
+
+	// Search bar
+	SearchCaseSensitive string
+	SearchWholeWord     string
+	SearchRegex         string
+
+	// Properties dialog
+	DialogPropertiesTitle string
+	PropFileName          string
+	PropLocation          string
+	PropTitle             string
+	PropAuthor            string
+	PropSubject           string
+	PropKeywords          string
+	PropCreator           string
+	PropProducer          string
+	PropCreated           string
+	PropModified          string
+	PropFormat            string
+	PropPDFVersion        string
+	PropEncryption        string
+	PropPageCount         string
+	PropPageSizes         string
+	PropOutlineDepth      string
+	PropFileSize          string
+	PropSHA256            string
+	PropNotEncrypted      string
+	PropEncrypted         string
+	PropPageRange         string
+	PropSinglePage        string
+	ButtonCopyJSON        string
+
+	// Export dialog
+	DialogExportTitle     string
+	LabelPageRange        string
+	LabelFormat           string
+	LabelDPI              string
+	LabelJPEGQuality      string
+	HintPageRange         string
+	DialogExportTextTitle string
+	TextFormatPlain       string
+	TextFormatHTML        string
+	TextFormatMarkdown    string
+
+	// Dialogs
+	DialogShortcutsTitle string
+	DialogShortcutsText  string
+	DialogAboutTitle     string
+	DialogAboutText      string
+	DialogOpenLinkTitle  string
+	DialogPasswordTitle  string
+	LabelPassword        string
+	ButtonCancel         string
+	DialogLayoutTitle    string
+	LabelFontSize        string
+	LabelPageSize        string
+	DialogSyncTeXTitle   string
+
+	// Toolbar hints
+	HintOpen      string
+	HintSaveAs    string
+	HintCloseTab  string
+	HintFirstPage string
+	HintPrevPage  string
+	HintNextPage  string
+	HintLastPage  string
+	HintPageEntry string
+	HintZoomOut   string
+	HintZoomIn    string
+	HintSearch    string
+}
+
+// GetTranslations 获取指定语言的翻译
+func GetTranslations(lang Language) *Translations {
+	switch lang {
+	case LangChinese:
+		return getChineseTranslations()
+	default:
+		return getEnglishTranslations()
+	}
+}
+
+// getEnglishTranslations 英文翻译
+func getEnglishTranslations() *Translations {
+	return &Translations{
+		WindowTitle: "PDF Reader",
+
+		MenuFile:        "File",
+		MenuOpen:        "Open...",
+		MenuNewTab:      "New Tab",
+		MenuSaveAs:      "Save As...",
+		MenuProperties:  "Properties...",
+		MenuExportPages: "Export Pages...",
+		MenuExportText:  "Export Text...",
+		MenuCloseTab:    "Close Tab",
+		MenuExit:        "Exit",
+
+		MenuView:          "View",
+		MenuFirstPage:     "First Page",
+		MenuPrevPage:      "Previous Page",
+		MenuNextPage:      "Next Page",
+		MenuLastPage:      "Last Page",
+		MenuZoomIn:        "Zoom In",
+		MenuZoomOut:       "Zoom Out",
+		MenuActualSize:    "Actual Size",
+		MenuFitWidth:      "Fit Width",
+		MenuFitPage:       "Fit Page",
+		MenuFitHeight:     "Fit Height",
+		MenuRotatePageCW:  "Rotate Page Clockwise",
+		MenuRotatePageCCW: "Rotate Page Counterclockwise",
+		MenuRotateViewCW:  "Rotate View Clockwise",
+		MenuRotateViewCCW: "Rotate View Counterclockwise",
+		MenuCopyPageText:  "Copy Page Text",
+		MenuFind:          "Find...",
+		MenuShowOutline:   "Outline",
+		MenuThumbnails:    "Thumbnails",
+		MenuContinuous:    "Continuous Scroll",
+		MenuSpread:        "Two-Page Spread",
+		MenuSpreadCover:   "Show Cover Alone",
+		MenuTextLayout:    "Text Layout...",
+
+		MenuHelp:      "Help",
+		MenuShortcuts: "Shortcuts",
+		MenuAbout:     "About",
+
+		MenuLanguage: "Language",
+		MenuEnglish:  "English",
+		MenuChinese:  "中文",
+
+		StatusNoDocument:  "No document open",
+		StatusPage:        "Page %d / %d",
+		StatusPages:       "Pages %d-%d / %d",
+		StatusZoom:        "Zoom",
+		StatusSize:        "Size",
+		StatusRotation:    "Rotation: %d°",
+		StatusSearching:   "Searching...",
+		StatusSearchHit:   "Match %d of %d",
+		StatusNoMatches:   "No matches",
+		StatusLinkPage:    "Go to page %d",
+		StatusReloadError: "Reload failed: %v",
+
+		MsgDoubleClickOpen:   "Double-click to open a document",
+		MsgLoading:           "Loading...",
+		MsgLoadFailed:        "Load failed: %v",
+		MsgRenderFailed:      "Render failed: %v",
+		MsgNoDocumentToSave:  "No document to save",
+		MsgSaveSuccess:       "File saved successfully",
+		MsgSaveFailed:        "Save failed: %v",
+		MsgInvalidPage:       "Invalid page number",
+		MsgTextCopied:        "Page text copied to clipboard",
+		MsgNoPageText:        "This page contains no extractable text",
+		MsgExtractTextFailed: "Text extraction failed: %v",
+		MsgSearchFailed:      "Search failed: %v",
+		MsgNoOutline:         "This document has no outline",
+		MsgOpenLinkConfirm:   "Open this link in your browser?\n\n%s",
+		MsgInvalidLink:       "Invalid link: %s",
+		MsgCorruptFile:       "The file is damaged or not a valid document: %v",
+		MsgPasswordRequired:  "\"%s\" is password protected",
+		MsgWrongPassword:     "Wrong password for \"%s\", please try again",
+		MsgPasswordCancelled: "A password is required to open this document",
+		MsgLayoutUnsupported: "Text layout can only be changed for EPUB, FB2 and MOBI documents",
+		MsgLayoutFailed:      "Re-layout failed: %v",
+		MsgPropertiesFailed:  "Failed to read document properties: %v",
+		MsgJSONCopied:        "Properties copied to clipboard as JSON",
+		MsgExportProgress:    "Exporting page %d of %d...",
+		MsgExportDone:        "Exported %d pages to %s",
+		MsgExportFailed:      "Export failed: %v",
+		MsgExportCancelled:   "Export cancelled after %d pages",
+		MsgInvalidDPI:        "DPI must be a number between 36 and 1200",
+		MsgTextExported:      "Text exported successfully",
+		MsgSyncTeXFailed:     "SyncTeX lookup failed: %v",
+		MsgSyncTeXSource:     "Source: %s\n\nStart with --editor or set PDFVIEWER_EDITOR to open it in your editor.",
+		MsgEditorFailed:      "Failed to start the editor: %v",
+
+		SearchCaseSensitive: "Match case",
+		SearchWholeWord:     "Whole word",
+		SearchRegex:         "Regex",
+
+		DialogPropertiesTitle: "Document Properties",
+		PropFileName:          "File name",
+		PropLocation:          "Location",
+		PropTitle:             "Title",
+		PropAuthor:            "Author",
+		PropSubject:           "Subject",
+		PropKeywords:          "Keywords",
+		PropCreator:           "Creator",
+		PropProducer:          "Producer",
+		PropCreated:           "Created",
+		PropModified:          "Modified",
+		PropFormat:            "Format",
+		PropPDFVersion:        "PDF version",
+		PropEncryption:        "Encryption",
+		PropPageCount:         "Pages",
+		PropPageSizes:         "Page size",
+		PropOutlineDepth:      "Outline depth",
+		PropFileSize:          "File size",
+		PropSHA256:            "SHA-256",
+		PropNotEncrypted:      "Not encrypted",
+		PropEncrypted:         "Encrypted",
+		PropPageRange:         "pages %d-%d",
+		PropSinglePage:        "page %d",
+		ButtonCopyJSON:        "Copy as JSON",
+
+		DialogExportTitle:     "Export Pages",
+		LabelPageRange:        "Pages",
+		LabelFormat:           "Format",
+		LabelDPI:              "DPI",
+		LabelJPEGQuality:      "JPEG quality",
+		HintPageRange:         "e.g. 1-3,7,10-",
+		DialogExportTextTitle: "Export Text",
+		TextFormatPlain:       "Plain text (.txt)",
+		TextFormatHTML:        "HTML (.html)",
+		TextFormatMarkdown:    "Markdown (.md)",
+
+		DialogShortcutsTitle: "Shortcuts",
+		DialogShortcutsText: `Keyboard Shortcuts:
+
+Navigation:
+  Left / PageUp      - Previous page
+  Right / PageDown   - Next page
+  Space              - Next page
+  Home               - First page
+  End                - Last page
+
+Search:
+  Ctrl+F             - Find in document
+  Enter              - Next match
+  Shift+Enter        - Previous match
+
+View:
+  Ctrl+R             - Rotate page clockwise
+  Ctrl+Shift+R       - Rotate page counterclockwise
+
+LaTeX:
+  Ctrl+Click         - Jump to source line (SyncTeX)
+
+Other:
+  Ctrl+W             - Close current tab
+`,
+
+		DialogAboutTitle: "About",
+		DialogAboutText: `PDF Reader v1.2.4
+
+Built with Fyne + go-fitz
+A simple and efficient PDF reading tool
+
+GitHub: https://github.com/tools4daily/pdfviewer
+
+Open Source Licenses:
+- Fyne: BSD-3-Clause
+- go-fitz: AGPL-3.0
+- pdfcpu: Apache-2.0
+- fsnotify: BSD-3-Clause
+`,
+		DialogOpenLinkTitle: "Open Link",
+		DialogPasswordTitle: "Password Required",
+		LabelPassword:       "Password",
+		ButtonCancel:        "Cancel",
+		DialogLayoutTitle:   "Text Layout",
+		LabelFontSize:       "Font size (pt)",
+		LabelPageSize:       "Page size (pt)",
+		DialogSyncTeXTitle:  "SyncTeX",
+
+		HintOpen:      "Open document",
+		HintSaveAs:    "Save as",
+		HintCloseTab:  "Close current tab",
+		HintFirstPage: "First page",
+		HintPrevPage:  "Previous page",
+		HintNextPage:  "Next page",
+		HintLastPage:  "Last page",
+		HintPageEntry: "Page number",
+		HintZoomOut:   "Zoom out",
+		HintZoomIn:    "Zoom in",
+		HintSearch:    "Search document",
+	}
+}
+
+// getChineseTranslations 中文翻译
+func getChineseTranslations() *Translations {
+	return &Translations{
+		WindowTitle: "PDF 阅读器",
+
+		MenuFile:        "文件",
+		MenuOpen:        "打开...",
+		MenuNewTab:      "新建标签页",
+		MenuSaveAs:      "另存为...",
+		MenuProperties:  "属性...",
+		MenuExportPages: "导出页面...",
+		MenuExportText:  "导出文本...",
+		MenuCloseTab:    "关闭标签页",
+		MenuExit:        "退出",
+
+		MenuView:          "查看",
+		MenuFirstPage:     "首页",
+		MenuPrevPage:      "上一页",
+		MenuNextPage:      "下一页",
+		MenuLastPage:      "末页",
+		MenuZoomIn:        "放大",
+		MenuZoomOut:       "缩小",
+		MenuActualSize:    "实际大小",
+		MenuFitWidth:      "适合宽度",
+		MenuFitPage:       "适合页面",
+		MenuFitHeight:     "适合高度",
+		MenuRotatePageCW:  "页面顺时针旋转",
+		MenuRotatePageCCW: "页面逆时针旋转",
+		MenuRotateViewCW:  "视图顺时针旋转",
+		MenuRotateViewCCW: "视图逆时针旋转",
+		MenuCopyPageText:  "复制页面文本",
+		MenuFind:          "查找...",
+		MenuShowOutline:   "目录",
+		MenuThumbnails:    "缩略图",
+		MenuContinuous:    "连续滚动",
+		MenuSpread:        "双页显示",
+		MenuSpreadCover:   "封面单独显示",
+		MenuTextLayout:    "文字排版...",
+
+		MenuHelp:      "帮助",
+		MenuShortcuts: "快捷键",
+		MenuAbout:     "关于",
+
+		MenuLanguage: "语言",
+		MenuEnglish:  "English",
+		MenuChinese:  "中文",
+
+		StatusNoDocument:  "未打开文档",
+		StatusPage:        "第 %d / %d 页",
+		StatusPages:       "第 %d-%d / %d 页",
+		StatusZoom:        "缩放",
+		StatusSize:        "大小",
+		StatusRotation:    "旋转: %d°",
+		StatusSearching:   "正在搜索...",
+		StatusSearchHit:   "第 %d / %d 个匹配",
+		StatusNoMatches:   "无匹配",
+		StatusLinkPage:    "跳转到第 %d 页",
+		StatusReloadError: "重新加载失败: %v",
+
+		MsgDoubleClickOpen:   "双击打开文档",
+		MsgLoading:           "正在加载...",
+		MsgLoadFailed:        "加载失败: %v",
+		MsgRenderFailed:      "渲染失败: %v",
+		MsgNoDocumentToSave:  "没有打开的文档可保存",
+		MsgSaveSuccess:       "文件已保存",
+		MsgSaveFailed:        "保存失败: %v",
+		MsgInvalidPage:       "无效的页码",
+		MsgTextCopied:        "页面文本已复制到剪贴板",
+		MsgNoPageText:        "此页面没有可提取的文本",
+		MsgExtractTextFailed: "提取文本失败: %v",
+		MsgSearchFailed:      "搜索失败: %v",
+		MsgNoOutline:         "此文档没有目录",
+		MsgOpenLinkConfirm:   "在浏览器中打开此链接？\n\n%s",
+		MsgInvalidLink:       "无效的链接: %s",
+		MsgCorruptFile:       "文件已损坏或不是有效的文档: %v",
+		MsgPasswordRequired:  "“%s” 受密码保护",
+		MsgWrongPassword:     "“%s” 的密码错误，请重试",
+		MsgPasswordCancelled: "需要密码才能打开此文档",
+		MsgLayoutUnsupported: "只有 EPUB、FB2 和 MOBI 文档可以调整文字排版",
+		MsgLayoutFailed:      "重新排版失败: %v",
+		MsgPropertiesFailed:  "读取文档属性失败: %v",
+		MsgJSONCopied:        "文档属性已以 JSON 格式复制到剪贴板",
+		MsgExportProgress:    "正在导出第 %d / %d 页...",
+		MsgExportDone:        "已导出 %d 页到 %s",
+		MsgExportFailed:      "导出失败: %v",
+		MsgExportCancelled:   "已取消导出，完成 %d 页",
+		MsgInvalidDPI:        "DPI 必须是 36 到 1200 之间的数字",
+		MsgTextExported:      "文本导出成功",
+		MsgSyncTeXFailed:     "SyncTeX 查找失败: %v",
+		MsgSyncTeXSource:     "源文件位置: %s\n\n使用 --editor 参数或设置 PDFVIEWER_EDITOR 环境变量即可直接在编辑器中打开。",
+		MsgEditorFailed:      "启动编辑器失败: %v",
+
+		SearchCaseSensitive: "区分大小写",
+		SearchWholeWord:     "全字匹配",
+		SearchRegex:         "正则表达式",
+
+		DialogPropertiesTitle: "文档属性",
+		PropFileName:          "文件名",
+		PropLocation:          "位置",
+		PropTitle:             "标题",
+		PropAuthor:            "作者",
+		PropSubject:           "主题",
+		PropKeywords:          "关键词",
+		PropCreator:           "创建工具",
+		PropProducer:          "生成工具",
+		PropCreated:           "创建时间",
+		PropModified:          "修改时间",
+		PropFormat:            "格式",
+		PropPDFVersion:        "PDF 版本",
+		PropEncryption:        "加密",
+		PropPageCount:         "页数",
+		PropPageSizes:         "页面尺寸",
+		PropOutlineDepth:      "目录层级",
+		PropFileSize:          "文件大小",
+		PropSHA256:            "SHA-256",
+		PropNotEncrypted:      "未加密",
+		PropEncrypted:         "已加密",
+		PropPageRange:         "第 %d-%d 页",
+		PropSinglePage:        "第 %d 页",
+		ButtonCopyJSON:        "复制为 JSON",
+
+		DialogExportTitle:     "导出页面",
+		LabelPageRange:        "页码范围",
+		LabelFormat:           "格式",
+		LabelDPI:              "DPI",
+		LabelJPEGQuality:      "JPEG 质量",
+		HintPageRange:         "例如 1-3,7,10-",
+		DialogExportTextTitle: "导出文本",
+		TextFormatPlain:       "纯文本 (.txt)",
+		TextFormatHTML:        "HTML (.html)",
+		TextFormatMarkdown:    "Markdown (.md)",
+
+		DialogShortcutsTitle: "快捷键",
+		DialogShortcutsText: `快捷键列表:
+
+导航:
+  左箭头 / PageUp    - 上一页
+  右箭头 / PageDown  - 下一页
+  空格键             - 下一页
+  Home              - 首页
+  End               - 末页
+
+搜索:
+  Ctrl+F            - 在文档中查找
+  Enter             - 下一个匹配
+  Shift+Enter       - 上一个匹配
+
+查看:
+  Ctrl+R            - 页面顺时针旋转
+  Ctrl+Shift+R      - 页面逆时针旋转
+
+LaTeX:
+  Ctrl+单击         - 跳转到源文件行（SyncTeX）
+
+其他:
+  Ctrl+W            - 关闭当前标签页
+`,
+
+		DialogAboutTitle: "关于",
+		DialogAboutText: `PDF 阅读器 v1.2.4
+
+基于 Fyne + go-fitz 开发
+简洁、高效的 PDF 阅读工具
+
+GitHub: https://github.com/tools4daily/pdfviewer
+
+开源许可:
+- Fyne: BSD-3-Clause
+- go-fitz: AGPL-3.0
+- pdfcpu: Apache-2.0
+- fsnotify: BSD-3-Clause
+`,
+		DialogOpenLinkTitle: "打开链接",
+		DialogPasswordTitle: "需要密码",
+		LabelPassword:       "密码",
+		ButtonCancel:        "取消",
+		DialogLayoutTitle:   "文字排版",
+		LabelFontSize:       "字号（磅）",
+		LabelPageSize:       "版面大小（磅）",
+		DialogSyncTeXTitle:  "SyncTeX",
+
+		HintOpen:      "打开文档",
+		HintSaveAs:    "另存为",
+		HintCloseTab:  "关闭当前标签页",
+		HintFirstPage: "首页",
+		HintPrevPage:  "上一页",
+		HintNextPage:  "下一页",
+		HintLastPage:  "末页",
+		HintPageEntry: "页码",
+		HintZoomOut:   "缩小",
+		HintZoomIn:    "放大",
+		HintSearch:    "搜索文档",
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// PageLink 页面上的链接注释
+// go-fitz 只提供链接地址，不提供链接区域，因此通过 pdfcpu 读取注释字典
+type PageLink struct {
+	Rect PageRect // 链接区域（页面坐标，左上角为原点）
+	URI  string   // 外部链接地址
+	Page int      // 内部链接的目标页（从 1 开始），外部链接为 0
+}
+
+// IsInternal 是否为文档内部跳转链接
+func (l PageLink) IsInternal() bool {
+	return l.Page > 0
+}
+
+// Contains 判断页面坐标是否落在链接区域内
+func (l PageLink) Contains(x, y float64) bool {
+	return x >= l.Rect.X && x <= l.Rect.X+l.Rect.W &&
+		y >= l.Rect.Y && y <= l.Rect.Y+l.Rect.H
+}
+
+// linkIndex 懒加载的链接解析上下文
+type linkIndex struct {
+	ctx       *model.Context
+	pageByObj map[int]int // 页面对象号 → 页码
+}
+
+// GetPageLinks 读取指定页面的链接
+func (e *PDFEngine) GetPageLinks(pageNum int) ([]PageLink, error) {
+	if n := e.GetPageCount(); pageNum < 1 || pageNum > n {
+		return nil, fmt.Errorf("页码超出范围: %d (总页数: %d)", pageNum, n)
+	}
+
+	// 链接注释通过 pdfcpu 读取，只适用于 PDF
+	if e.format != FormatPDF {
+		return nil, nil
+	}
+
+	// 使用单独的锁：解析整个文件较慢，不能阻塞页面渲染；pdfcpu 上下文也不是并发安全的
+	e.linksMu.Lock()
+	defer e.linksMu.Unlock()
+
+	idx, err := e.linkIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+	return idx.pageLinks(pageNum)
+}
+
+// linkIndexLocked 返回链接索引，首次调用时解析文档结构（调用方持有 linksMu）
+// 失败结果也会缓存，避免每次渲染都重新解析
+func (e *PDFEngine) linkIndexLocked() (*linkIndex, error) {
+	if e.links == nil && e.linksErr == nil {
+		e.links, e.linksErr = e.loadLinkIndex()
+	}
+	return e.links, e.linksErr
+}
+
+// loadLinkIndex 解析文档结构
+func (e *PDFEngine) loadLinkIndex() (*linkIndex, error) {
+	rs, closeSource, err := e.openSource()
+	if err != nil {
+		return nil, fmt.Errorf("读取链接失败: %w", err)
+	}
+	defer closeSource()
+
+	ctx, err := api.ReadContext(rs, pdfcpuConfig(e.password))
+	if err != nil {
+		return nil, fmt.Errorf("读取链接失败: %w", err)
+	}
+
+	idx := &linkIndex{ctx: ctx, pageByObj: map[int]int{}}
+	for page := 1; page <= ctx.PageCount; page++ {
+		_, ref, _, err := ctx.PageDict(page, false)
+		if err == nil && ref != nil {
+			idx.pageByObj[ref.ObjectNumber.Value()] = page
+		}
+	}
+
+	return idx, nil
+}
+
+// pageLinks 解析页面 /Annots 中的链接注释
+func (idx *linkIndex) pageLinks(pageNum int) ([]PageLink, error) {
+	pageDict, _, inherited, err := idx.ctx.PageDict(pageNum, false)
+	if err != nil {
+		return nil, fmt.Errorf("读取链接失败: %w", err)
+	}
+
+	annots, err := idx.ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || len(annots) == 0 {
+		return nil, nil
+	}
+
+	// 页面显示区域，用于把 PDF 坐标（左下角为原点）转换为页面坐标
+	if inherited == nil || (inherited.CropBox == nil && inherited.MediaBox == nil) {
+		return nil, nil
+	}
+	box := inherited.CropBox
+	if box == nil {
+		box = inherited.MediaBox
+	}
+
+	var links []PageLink
+	for _, obj := range annots {
+		annot, err := idx.ctx.DereferenceDict(obj)
+		if err != nil || annot == nil {
+			continue
+		}
+		if subtype := annot.NameEntry("Subtype"); subtype == nil || *subtype != "Link" {
+			continue
+		}
+
+		rect, ok := idx.rect(annot["Rect"])
+		if !ok {
+			continue
+		}
+		link := PageLink{Rect: toPageRect(rect, box, inherited.Rotate)}
+		link.URI, link.Page = idx.target(annot)
+		if link.URI == "" && link.Page == 0 {
+			continue
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// target 解析链接目标：/A 动作（URI 或 GoTo）或 /Dest
+func (idx *linkIndex) target(annot types.Dict) (uri string, page int) {
+	if action, err := idx.ctx.DereferenceDict(annot["A"]); err == nil && action != nil {
+		switch s := action.NameEntry("S"); {
+		case s == nil:
+		case *s == "URI":
+			return idx.text(action["URI"]), 0
+		case *s == "GoTo":
+			return "", idx.destPage(action["D"])
+		}
+		return "", 0
+	}
+	return "", idx.destPage(annot["Dest"])
+}
+
+// destPage 解析目标（显式数组或命名目标）对应的页码
+func (idx *linkIndex) destPage(obj types.Object) int {
+	obj, err := idx.ctx.Dereference(obj)
+	if err != nil || obj == nil {
+		return 0
+	}
+
+	var dest types.Array
+	switch d := obj.(type) {
+	case types.Array:
+		dest = d
+	case types.Name:
+		dest, _ = idx.ctx.DereferenceDestArray(d.Value())
+	case types.StringLiteral, types.HexLiteral:
+		dest, _ = idx.ctx.DereferenceDestArray(idx.text(d))
+	case types.Dict:
+		return idx.destPage(d["D"])
+	}
+	if len(dest) == 0 {
+		return 0
+	}
+
+	switch p := dest[0].(type) {
+	case types.IndirectRef:
+		return idx.pageByObj[p.ObjectNumber.Value()]
+	case types.Integer:
+		return p.Value() + 1 // 远程目标使用从 0 开始的页码
+	}
+	return 0
+}
+
+// rect 解析 [llx lly urx ury] 数组
+func (idx *linkIndex) rect(obj types.Object) (types.Rectangle, bool) {
+	arr, err := idx.ctx.DereferenceArray(obj)
+	if err != nil || len(arr) != 4 {
+		return types.Rectangle{}, false
+	}
+
+	var v [4]float64
+	for i, o := range arr {
+		o, _ = idx.ctx.Dereference(o)
+		switch n := o.(type) {
+		case types.Integer:
+			v[i] = float64(n.Value())
+		case types.Float:
+			v[i] = n.Value()
+		default:
+			return types.Rectangle{}, false
+		}
+	}
+	return *types.NewRectangle(v[0], v[1], v[2], v[3]), true
+}
+
+// text 解析字符串对象
+func (idx *linkIndex) text(obj types.Object) string {
+	obj, err := idx.ctx.Dereference(obj)
+	if err != nil {
+		return ""
+	}
+
+	switch s := obj.(type) {
+	case types.StringLiteral:
+		text, err := types.StringLiteralToString(s)
+		if err == nil {
+			return text
+		}
+	case types.HexLiteral:
+		text, err := types.HexLiteralToString(s)
+		if err == nil {
+			return text
+		}
+	}
+	return ""
+}
+
+// toPageRect 将 PDF 坐标转换为以显示区域左上角为原点的页面坐标
+// rotate 为页面的 /Rotate（顺时针），MuPDF 渲染时已按它旋转页面，链接区域也要一起旋转
+func toPageRect(r types.Rectangle, box *types.Rectangle, rotate int) PageRect {
+	llx, lly := minFloat(r.LL.X, r.UR.X), minFloat(r.LL.Y, r.UR.Y)
+	urx, ury := maxFloat(r.LL.X, r.UR.X), maxFloat(r.LL.Y, r.UR.Y)
+	rect := PageRect{X: llx - box.LL.X, Y: box.UR.Y - ury, W: urx - llx, H: ury - lly}
+
+	w, h := box.Width(), box.Height()
+	switch ((rotate % 360) + 360) % 360 {
+	case 90:
+		return PageRect{X: h - rect.Y - rect.H, Y: rect.X, W: rect.H, H: rect.W}
+	case 180:
+		return PageRect{X: w - rect.X - rect.W, Y: h - rect.Y - rect.H, W: rect.W, H: rect.H}
+	case 270:
+		return PageRect{X: rect.Y, Y: w - rect.X - rect.W, W: rect.H, H: rect.W}
+	}
+	return rect
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
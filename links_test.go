@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestToPageRectRotation(t *testing.T) {
+	box := types.NewRectangle(0, 0, 200, 300)
+	link := *types.NewRectangle(10, 250, 50, 280)
+	tests := []struct {
+		rotate int
+		want   PageRect
+	}{
+		{0, PageRect{X: 10, Y: 20, W: 40, H: 30}},
+		{90, PageRect{X: 250, Y: 10, W: 30, H: 40}},
+		{180, PageRect{X: 150, Y: 250, W: 40, H: 30}},
+		{270, PageRect{X: 20, Y: 150, W: 30, H: 40}},
+		{-90, PageRect{X: 20, Y: 150, W: 30, H: 40}},
+	}
+	for _, tt := range tests {
+		if got := toPageRect(link, box, tt.rotate); got != tt.want {
+			t.Errorf("toPageRect(rotate=%d) = %+v, want %+v", tt.rotate, got, tt.want)
+		}
+	}
+}
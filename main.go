@@ -1,25 +1,100 @@
-package main
-
-import (
-	"os"
-
-	"fyne.io/fyne/v2/app"
-)
-
-func main() {
-	// 创建 Fyne 应用
-	myApp := app.New()
-	myApp.Settings().SetTheme(&customTheme{})
-
-	// 创建界面（不再需要传递 controller）
-	ui := NewViewerUI(myApp, nil)
-
-	// 如果有命令行参数，在当前标签页打开文件
-	if len(os.Args) > 1 {
-		filePath := os.Args[1]
-		ui.openFileInCurrentTab(filePath)
-	}
-
-	// 显示窗口并运行
-	ui.Show()
-}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"fyne.io/fyne/v2/app"
+)
+
+func main() {
+	// 子命令（render 等）在无界面模式下运行
+	if code, ok := runSubcommand(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
+	// 解析命令行参数
+	password := flag.String("password", "", "打开命令行指定的加密 PDF 时使用的密码")
+	page := flag.Int("page", 0, "打开后跳转到的页码")
+	search := flag.String("search", "", "打开后在文档中搜索的文本")
+	forward := flag.String("forward", "", "SyncTeX 正向搜索：打开文档后跳转到源文件位置 file.tex:line 对应的页面并高亮")
+	editor := flag.String("editor", os.Getenv("PDFVIEWER_EDITOR"), "Ctrl+单击反向搜索时执行的编辑器命令，%f 替换为源文件，%l 替换为行号（默认读取 PDFVIEWER_EDITOR）")
+	newInstance := flag.Bool("new-instance", false, "总是启动新的窗口，不交给正在运行的实例")
+	prefetch := flag.Int("prefetch", defaultPrefetchCount, "渲染后在后台预取的相邻页数，0 表示关闭预取")
+	prefetchDirectional := flag.Bool("prefetch-directional", true, "沿翻页方向预取，反方向只预取 1 页（设为 false 时前后两侧交替预取）")
+	cacheMB := flag.Int("cache-mb", defaultCacheBudget>>20, "页面图像缓存的内存预算（MB），0 表示不缓存")
+	cacheStats := flag.Bool("cache-stats", false, "退出时在标准错误输出页面缓存的命中统计")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--password <password>] [--page n] [--search text] [--forward file.tex:line] [--editor <command>] [--new-instance] [file.pdf | URI | -]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s render <file> [--pages 1-5] [--dpi 200] [--format png] [-o out/]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s info <file>... [--format json|text]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s text <file> [--pages 1-5] [--format txt|html|md]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s grep [-i] [-C n] <pattern> <file | dir>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *prefetch < 0 {
+		fmt.Fprintf(os.Stderr, "无效的预取页数: %d\n", *prefetch)
+		os.Exit(exitUsage)
+	}
+	if *cacheMB < 0 {
+		fmt.Fprintf(os.Stderr, "无效的缓存预算: %d MB\n", *cacheMB)
+		os.Exit(exitUsage)
+	}
+	req, err := buildOpenRequest(flag.Arg(0), *password, *page, *search, *forward)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// 已有实例在运行时把参数交给它，本进程退出；标准输入只能由本进程读取
+	if !*newInstance && req.File != stdinArg {
+		sent, err := sendOpenRequest(req)
+		if sent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(exitError)
+			}
+			os.Exit(exitOK)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "无法连接正在运行的实例，启动新实例: %v\n", err)
+		}
+	}
+
+	sharedPageCache.SetBudget(int64(*cacheMB) << 20)
+
+	// 创建 Fyne 应用
+	myApp := app.New()
+	myApp.Settings().SetTheme(&customTheme{})
+
+	// 创建界面（不再需要传递 controller）
+	ui := NewViewerUI(myApp, nil)
+	ui.editor = *editor
+	ui.SetPrefetch(*prefetch, *prefetchDirectional)
+
+	// 接收之后启动的进程转交的文档
+	if !*newInstance {
+		server, err := listenOpenRequests(func(req openRequest) error {
+			ui.window.RequestFocus()
+			return <-ui.openRequest(req)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "单实例模式不可用: %v\n", err)
+		} else {
+			defer server.Close()
+		}
+	}
+
+	// 如果有命令行参数，在当前标签页打开文件（- 表示从标准输入读取）
+	ui.openRequest(req)
+
+	// 显示窗口并运行
+	ui.Show()
+
+	if *cacheStats {
+		fmt.Fprintf(os.Stderr, "页面缓存: %s\n", sharedPageCache.Stats())
+	}
+}
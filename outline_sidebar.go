@@ -0,0 +1,180 @@
+package main
+
+import (
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// sidebarWidth 侧边栏默认宽度
+const sidebarWidth = 220
+
+// outlineSidebar 标签页的目录侧边栏
+type outlineSidebar struct {
+	items    []OutlineItem
+	children map[widget.TreeNodeID][]widget.TreeNodeID
+	parents  map[widget.TreeNodeID]widget.TreeNodeID
+
+	tree         *widget.Tree
+	emptyLabel   *widget.Label
+	container    *fyne.Container
+	syncing      bool // 正在根据当前页同步选中项，忽略选中回调
+	onNavigate   func(page int)
+	selectedNode widget.TreeNodeID
+}
+
+// newOutlineSidebar 创建目录侧边栏（默认隐藏）
+func newOutlineSidebar(emptyText string, onNavigate func(page int)) *outlineSidebar {
+	sb := &outlineSidebar{
+		children:   map[widget.TreeNodeID][]widget.TreeNodeID{},
+		parents:    map[widget.TreeNodeID]widget.TreeNodeID{},
+		onNavigate: onNavigate,
+	}
+
+	sb.tree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			return sb.children[id]
+		},
+		func(id widget.TreeNodeID) bool {
+			return len(sb.children[id]) > 0
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			if item, ok := sb.item(id); ok {
+				obj.(*widget.Label).SetText(item.Title)
+			}
+		},
+	)
+	sb.tree.OnSelected = func(id widget.TreeNodeID) {
+		sb.selectedNode = id
+		if sb.syncing {
+			return
+		}
+		if item, ok := sb.item(id); ok && item.Page > 0 && sb.onNavigate != nil {
+			sb.onNavigate(item.Page)
+		}
+	}
+
+	sb.emptyLabel = widget.NewLabel(emptyText)
+	sb.emptyLabel.Wrapping = fyne.TextWrapWord
+	sb.emptyLabel.Hide()
+
+	// 透明占位矩形撑开侧边栏宽度
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(sidebarWidth, 0))
+
+	sb.container = container.NewStack(spacer, sb.tree, container.NewVBox(sb.emptyLabel))
+	sb.container.Hide()
+	return sb
+}
+
+// SetItems 设置目录条目并重建树结构
+func (sb *outlineSidebar) SetItems(items []OutlineItem) {
+	sb.items = items
+	sb.children = map[widget.TreeNodeID][]widget.TreeNodeID{}
+	sb.parents = map[widget.TreeNodeID]widget.TreeNodeID{}
+	sb.selectedNode = ""
+
+	// 按层级把扁平列表组织成树：栈中保存当前路径上的祖先节点
+	type ancestor struct {
+		level int
+		id    widget.TreeNodeID
+	}
+	var stack []ancestor
+	for i, item := range items {
+		for len(stack) > 0 && stack[len(stack)-1].level >= item.Level {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := ""
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1].id
+		}
+
+		id := strconv.Itoa(i)
+		sb.children[parent] = append(sb.children[parent], id)
+		sb.parents[id] = parent
+		stack = append(stack, ancestor{level: item.Level, id: id})
+	}
+
+	if len(items) == 0 {
+		sb.emptyLabel.Show()
+	} else {
+		sb.emptyLabel.Hide()
+	}
+	sb.tree.UnselectAll()
+	sb.tree.Refresh()
+}
+
+// SyncToPage 高亮包含当前页的章节
+func (sb *outlineSidebar) SyncToPage(page int) {
+	id, ok := sb.sectionForPage(page)
+	if !ok {
+		sb.tree.UnselectAll()
+		sb.selectedNode = ""
+		return
+	}
+	if id == sb.selectedNode {
+		return
+	}
+
+	// 展开所有祖先节点，使选中项可见
+	for parent := sb.parents[id]; parent != ""; parent = sb.parents[parent] {
+		sb.tree.OpenBranch(parent)
+	}
+
+	sb.syncing = true
+	sb.tree.Select(id)
+	sb.syncing = false
+	sb.tree.ScrollTo(id)
+}
+
+// sectionForPage 返回起始页不晚于 page 的最后一个条目
+func (sb *outlineSidebar) sectionForPage(page int) (widget.TreeNodeID, bool) {
+	best := -1
+	for i, item := range sb.items {
+		if item.Page > 0 && item.Page <= page {
+			if best < 0 || item.Page >= sb.items[best].Page {
+				best = i
+			}
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return strconv.Itoa(best), true
+}
+
+// item 根据节点 ID 查找条目
+func (sb *outlineSidebar) item(id widget.TreeNodeID) (OutlineItem, bool) {
+	i, err := strconv.Atoi(id)
+	if err != nil || i < 0 || i >= len(sb.items) {
+		return OutlineItem{}, false
+	}
+	return sb.items[i], true
+}
+
+// SetEmptyText 设置无目录时的提示文本
+func (sb *outlineSidebar) SetEmptyText(text string) {
+	sb.emptyLabel.SetText(text)
+}
+
+// SetVisible 显示或隐藏侧边栏
+func (sb *outlineSidebar) SetVisible(visible bool) {
+	if visible {
+		sb.container.Show()
+	} else {
+		sb.container.Hide()
+	}
+}
+
+// Visible 侧边栏是否可见
+func (sb *outlineSidebar) Visible() bool {
+	return sb.container.Visible()
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"image"
+	"sync"
+)
+
+// defaultCacheBudget 默认页面图像缓存预算（字节）
+const defaultCacheBudget = 256 * 1024 * 1024
+
+// sharedPageCache 所有标签页共享的页面图像缓存
+var sharedPageCache = newPageCache(defaultCacheBudget)
+
+// pageCacheKey 缓存键：文档 + 页码 + DPI + 渲染选项
+type pageCacheKey struct {
+	docID uint64
+	page  int
+	dpi   int
+	opts  RenderOptions
+}
+
+// pageCacheEntry 缓存项
+type pageCacheEntry struct {
+	key  pageCacheKey
+	img  image.Image
+	size int64
+}
+
+// CacheStats 缓存命中统计
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+	Bytes   int64
+	Budget  int64
+}
+
+// String 返回便于阅读的统计信息，用于调整缓存预算
+func (s CacheStats) String() string {
+	rate := 0.0
+	if total := s.Hits + s.Misses; total > 0 {
+		rate = float64(s.Hits) * 100 / float64(total)
+	}
+	return fmt.Sprintf("命中 %d，未命中 %d（命中率 %.1f%%），%d 项，%s / %s",
+		s.Hits, s.Misses, rate, s.Entries, formatFileSize(s.Bytes), formatFileSize(s.Budget))
+}
+
+// pageCache 按字节预算淘汰的 LRU 页面图像缓存
+type pageCache struct {
+	mu      sync.Mutex
+	budget  int64
+	used    int64
+	order   *list.List // 最近使用的在前
+	entries map[pageCacheKey]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+// newPageCache 创建指定预算的缓存
+func newPageCache(budget int64) *pageCache {
+	return &pageCache{
+		budget:  budget,
+		order:   list.New(),
+		entries: make(map[pageCacheKey]*list.Element),
+	}
+}
+
+// Get 查找缓存图像，命中时将其移到最前
+func (c *pageCache) Get(key pageCacheKey) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*pageCacheEntry).img, true
+}
+
+// Contains 检查是否已缓存（不影响命中统计和淘汰顺序）
+func (c *pageCache) Contains(key pageCacheKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Put 存入图像，超出预算时淘汰最久未使用的项
+func (c *pageCache) Put(key pageCacheKey, img image.Image) {
+	size := imageByteSize(img)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 单张图像超过预算时不缓存
+	if size > c.budget {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*pageCacheEntry)
+		c.used += size - entry.size
+		entry.img = img
+		entry.size = size
+		c.order.MoveToFront(elem)
+	} else {
+		entry := &pageCacheEntry{key: key, img: img, size: size}
+		c.entries[key] = c.order.PushFront(entry)
+		c.used += size
+	}
+
+	c.evictLocked()
+}
+
+// RemoveDocument 移除某个文档的全部缓存项
+func (c *pageCache) RemoveDocument(docID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.entries {
+		if key.docID == docID {
+			c.removeLocked(elem)
+		}
+	}
+}
+
+// SetBudget 调整缓存预算
+func (c *pageCache) SetBudget(budget int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.budget = budget
+	c.evictLocked()
+}
+
+// Stats 返回缓存统计信息
+func (c *pageCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Entries: len(c.entries),
+		Bytes:   c.used,
+		Budget:  c.budget,
+	}
+}
+
+// evictLocked 淘汰最久未使用的项直到不超预算（调用方持有锁）
+func (c *pageCache) evictLocked() {
+	for c.used > c.budget {
+		oldest := c.order.Back()
+		if oldest == nil {
+			return
+		}
+		c.removeLocked(oldest)
+	}
+}
+
+// removeLocked 移除单个缓存项（调用方持有锁）
+func (c *pageCache) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*pageCacheEntry)
+	c.order.Remove(elem)
+	delete(c.entries, entry.key)
+	c.used -= entry.size
+}
+
+// imageByteSize 估算图像占用的内存
+func imageByteSize(img image.Image) int64 {
+	switch im := img.(type) {
+	case *image.RGBA:
+		return int64(len(im.Pix))
+	case *image.NRGBA:
+		return int64(len(im.Pix))
+	case *image.Gray:
+		return int64(len(im.Pix))
+	}
+	b := img.Bounds()
+	return int64(b.Dx()) * int64(b.Dy()) * 4
+}
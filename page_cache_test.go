@@ -0,0 +1,111 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+// testImage 创建 n 字节的 RGBA 图像（n 为 4 的倍数）
+func testImage(n int) *image.RGBA {
+	return image.NewRGBA(image.Rect(0, 0, n/4, 1))
+}
+
+func testCacheKey(doc uint64, page int) pageCacheKey {
+	return pageCacheKey{docID: doc, page: page, dpi: 96}
+}
+
+func TestPageCacheGetMovesToFront(t *testing.T) {
+	c := newPageCache(300)
+	c.Put(testCacheKey(1, 1), testImage(100))
+	c.Put(testCacheKey(1, 2), testImage(100))
+	c.Put(testCacheKey(1, 3), testImage(100))
+
+	// 读取第 1 页后，最久未使用的变为第 2 页
+	if _, ok := c.Get(testCacheKey(1, 1)); !ok {
+		t.Fatal("Get(page 1) missed")
+	}
+	c.Put(testCacheKey(1, 4), testImage(100))
+
+	if !c.Contains(testCacheKey(1, 1)) {
+		t.Error("page 1 was evicted although it was used most recently")
+	}
+	if c.Contains(testCacheKey(1, 2)) {
+		t.Error("page 2 should be evicted as the least recently used entry")
+	}
+}
+
+func TestPageCacheEvictsOverBudget(t *testing.T) {
+	c := newPageCache(250)
+	c.Put(testCacheKey(1, 1), testImage(100))
+	c.Put(testCacheKey(1, 2), testImage(100))
+	c.Put(testCacheKey(1, 3), testImage(100))
+
+	stats := c.Stats()
+	if stats.Entries != 2 || stats.Bytes != 200 {
+		t.Errorf("Stats() = %d entries, %d bytes; want 2 entries, 200 bytes", stats.Entries, stats.Bytes)
+	}
+	if c.Contains(testCacheKey(1, 1)) {
+		t.Error("the oldest page should be evicted when Put exceeds the budget")
+	}
+}
+
+func TestPageCacheSkipsOversizeImage(t *testing.T) {
+	c := newPageCache(100)
+	c.Put(testCacheKey(1, 1), testImage(80))
+	c.Put(testCacheKey(1, 2), testImage(200))
+
+	if c.Contains(testCacheKey(1, 2)) {
+		t.Error("an image larger than the budget should not be cached")
+	}
+	if !c.Contains(testCacheKey(1, 1)) {
+		t.Error("an oversize image should not evict other entries")
+	}
+}
+
+func TestPageCacheSetBudgetShrinks(t *testing.T) {
+	c := newPageCache(400)
+	for page := 1; page <= 4; page++ {
+		c.Put(testCacheKey(1, page), testImage(100))
+	}
+
+	c.SetBudget(150)
+	stats := c.Stats()
+	if stats.Entries != 1 || stats.Bytes != 100 || stats.Budget != 150 {
+		t.Errorf("Stats() after SetBudget(150) = %+v; want 1 entry, 100 bytes, budget 150", stats)
+	}
+	if !c.Contains(testCacheKey(1, 4)) {
+		t.Error("the most recently used page should survive a smaller budget")
+	}
+}
+
+func TestPageCacheRemoveDocument(t *testing.T) {
+	c := newPageCache(1000)
+	c.Put(testCacheKey(1, 1), testImage(100))
+	c.Put(testCacheKey(1, 2), testImage(100))
+	c.Put(testCacheKey(2, 1), testImage(100))
+
+	c.RemoveDocument(1)
+	if c.Contains(testCacheKey(1, 1)) || c.Contains(testCacheKey(1, 2)) {
+		t.Error("RemoveDocument(1) left pages of document 1 in the cache")
+	}
+	if !c.Contains(testCacheKey(2, 1)) {
+		t.Error("RemoveDocument(1) removed a page of document 2")
+	}
+	if stats := c.Stats(); stats.Bytes != 100 {
+		t.Errorf("Stats().Bytes after RemoveDocument = %d, want 100", stats.Bytes)
+	}
+}
+
+func TestCacheStatsString(t *testing.T) {
+	c := newPageCache(1024 * 1024)
+	key := pageCacheKey{docID: 1, page: 1, dpi: 72}
+
+	c.Get(key)
+	c.Put(key, image.NewRGBA(image.Rect(0, 0, 16, 16)))
+	c.Get(key)
+
+	want := "命中 1，未命中 1（命中率 50.0%），1 项，1.0 KB / 1.0 MB"
+	if got := c.Stats().String(); got != want {
+		t.Errorf("Stats().String() = %q, want %q", got, want)
+	}
+}
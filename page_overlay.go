@@ -0,0 +1,131 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	highlightColor        = color.NRGBA{R: 255, G: 220, B: 0, A: 90}
+	currentHighlightColor = color.NRGBA{R: 255, G: 120, B: 0, A: 130}
+	syncHighlightColor    = color.NRGBA{R: 0, G: 120, B: 255, A: 70}
+)
+
+// overlayRect 叠加层上的一个矩形（页面坐标）
+type overlayRect struct {
+	rect  PageRect
+	color color.Color
+}
+
+// pageOverlay 叠加在页面图像上方，用于绘制搜索高亮等标记
+// 页面坐标按当前图像的 DPI 换算为像素，再按图像在控件中的实际显示区域定位
+type pageOverlay struct {
+	widget.BaseWidget
+	imgW, imgH float32 // 当前图像的像素尺寸
+	scale      float32 // 每点对应的像素数（dpi / 72）
+	rects      []overlayRect
+}
+
+func newPageOverlay() *pageOverlay {
+	o := &pageOverlay{}
+	o.ExtendBaseWidget(o)
+	return o
+}
+
+// SetPage 设置当前显示图像的尺寸和 DPI
+func (o *pageOverlay) SetPage(imgW, imgH, dpi int) {
+	o.imgW = float32(imgW)
+	o.imgH = float32(imgH)
+	o.scale = float32(dpi) / 72
+	o.Refresh()
+}
+
+// SetRects 替换要绘制的矩形
+func (o *pageOverlay) SetRects(rects []overlayRect) {
+	o.rects = rects
+	o.Refresh()
+}
+
+// imageArea 计算图像在控件中的显示位置和缩放（与 canvas.Image 的等比居中显示一致）
+func (o *pageOverlay) imageArea(size fyne.Size) (offset fyne.Position, ratio float32) {
+	if o.imgW <= 0 || o.imgH <= 0 {
+		return fyne.NewPos(0, 0), 0
+	}
+
+	ratio = size.Width / o.imgW
+	if r := size.Height / o.imgH; r < ratio {
+		ratio = r
+	}
+	offset = fyne.NewPos((size.Width-o.imgW*ratio)/2, (size.Height-o.imgH*ratio)/2)
+	return offset, ratio
+}
+
+// PagePointToPosition 将页面坐标（点）转换为控件内坐标
+func (o *pageOverlay) PagePointToPosition(x, y float64) fyne.Position {
+	offset, ratio := o.imageArea(o.Size())
+	return fyne.NewPos(
+		offset.X+float32(x)*o.scale*ratio,
+		offset.Y+float32(y)*o.scale*ratio,
+	)
+}
+
+// PositionToPagePoint 将控件内坐标转换为页面坐标（点），不在图像范围内时返回 false
+func (o *pageOverlay) PositionToPagePoint(pos fyne.Position) (x, y float64, ok bool) {
+	offset, ratio := o.imageArea(o.Size())
+	k := o.scale * ratio
+	if k <= 0 {
+		return 0, 0, false
+	}
+
+	px := pos.X - offset.X
+	py := pos.Y - offset.Y
+	if px < 0 || py < 0 || px > o.imgW*ratio || py > o.imgH*ratio {
+		return 0, 0, false
+	}
+	return float64(px / k), float64(py / k), true
+}
+
+func (o *pageOverlay) CreateRenderer() fyne.WidgetRenderer {
+	r := &pageOverlayRenderer{overlay: o}
+	r.Refresh()
+	return r
+}
+
+type pageOverlayRenderer struct {
+	overlay *pageOverlay
+	objects []fyne.CanvasObject
+}
+
+func (r *pageOverlayRenderer) Layout(size fyne.Size) {
+	o := r.overlay
+	offset, ratio := o.imageArea(size)
+	k := o.scale * ratio
+
+	for i, obj := range r.objects {
+		rect := o.rects[i].rect
+		obj.Move(fyne.NewPos(offset.X+float32(rect.X)*k, offset.Y+float32(rect.Y)*k))
+		obj.Resize(fyne.NewSize(float32(rect.W)*k, float32(rect.H)*k))
+	}
+}
+
+func (r *pageOverlayRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func (r *pageOverlayRenderer) Refresh() {
+	r.objects = make([]fyne.CanvasObject, len(r.overlay.rects))
+	for i, rect := range r.overlay.rects {
+		r.objects[i] = canvas.NewRectangle(rect.color)
+	}
+	r.Layout(r.overlay.Size())
+	canvas.Refresh(r.overlay)
+}
+
+func (r *pageOverlayRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *pageOverlayRenderer) Destroy() {}
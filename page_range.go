@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parsePageRange 解析页码范围，例如 "1-3,7,10-"
+// "10-" 表示第 10 页到最后一页，"-5" 表示第 1 页到第 5 页，空字符串表示全部页面
+// 返回的页码按书写顺序排列并去重
+func parsePageRange(spec string, pageCount int) ([]int, error) {
+	if pageCount < 1 {
+		return nil, fmt.Errorf("文档没有页面")
+	}
+
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		spec = "1-"
+	}
+
+	seen := make(map[int]bool)
+	var pages []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		first, last, err := parseRangePart(part, pageCount)
+		if err != nil {
+			return nil, err
+		}
+		for page := first; page <= last; page++ {
+			if !seen[page] {
+				seen[page] = true
+				pages = append(pages, page)
+			}
+		}
+	}
+
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("无效的页码范围: %q", spec)
+	}
+	return pages, nil
+}
+
+// parseRangePart 解析单个范围片段（"7"、"1-3"、"10-" 或 "-5"）
+func parseRangePart(part string, pageCount int) (int, int, error) {
+	parsePage := func(s string, def int) (int, error) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return def, nil
+		}
+		page, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("无效的页码范围: %q", part)
+		}
+		if page < 1 || page > pageCount {
+			return 0, fmt.Errorf("页码超出范围: %d (1-%d)", page, pageCount)
+		}
+		return page, nil
+	}
+
+	from, to, isRange := strings.Cut(part, "-")
+	if !isRange {
+		page, err := parsePage(from, 0)
+		return page, page, err
+	}
+
+	first, err := parsePage(from, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	last, err := parsePage(to, pageCount)
+	if err != nil {
+		return 0, 0, err
+	}
+	if first > last {
+		return 0, 0, fmt.Errorf("无效的页码范围: %q", part)
+	}
+	return first, last, nil
+}
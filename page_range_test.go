@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		pageCount int
+		want      []int
+		wantErr   bool
+	}{
+		{name: "empty means all pages", spec: "", pageCount: 3, want: []int{1, 2, 3}},
+		{name: "blank means all pages", spec: "  ", pageCount: 2, want: []int{1, 2}},
+		{name: "ranges and open end", spec: "1-3,7,10-", pageCount: 12, want: []int{1, 2, 3, 7, 10, 11, 12}},
+		{name: "open start", spec: "-5", pageCount: 8, want: []int{1, 2, 3, 4, 5}},
+		{name: "dash alone", spec: "-", pageCount: 3, want: []int{1, 2, 3}},
+		{name: "single page", spec: "4", pageCount: 4, want: []int{4}},
+		{name: "spaces", spec: " 2 - 3 , 5 ", pageCount: 5, want: []int{2, 3, 5}},
+		{name: "written order", spec: "5,1-2", pageCount: 5, want: []int{5, 1, 2}},
+		{name: "duplicates removed", spec: "1-3,2,3-4,1", pageCount: 5, want: []int{1, 2, 3, 4}},
+		{name: "empty parts skipped", spec: "1,,3,", pageCount: 3, want: []int{1, 3}},
+		{name: "page zero", spec: "0", pageCount: 5, wantErr: true},
+		{name: "range from zero", spec: "0-2", pageCount: 5, wantErr: true},
+		{name: "reversed range", spec: "5-3", pageCount: 5, wantErr: true},
+		{name: "page past end", spec: "6", pageCount: 5, wantErr: true},
+		{name: "range past end", spec: "3-9", pageCount: 5, wantErr: true},
+		{name: "open end past end", spec: "9-", pageCount: 5, wantErr: true},
+		{name: "negative page", spec: "--2", pageCount: 5, wantErr: true},
+		{name: "not a number", spec: "a-b", pageCount: 5, wantErr: true},
+		{name: "only commas", spec: ",,", pageCount: 5, wantErr: true},
+		{name: "no pages", spec: "1", pageCount: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePageRange(tt.spec, tt.pageCount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePageRange(%q, %d) = %v, want error", tt.spec, tt.pageCount, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePageRange(%q, %d) error = %v", tt.spec, tt.pageCount, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePageRange(%q, %d) = %v, want %v", tt.spec, tt.pageCount, got, tt.want)
+			}
+		})
+	}
+}
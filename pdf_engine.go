@@ -1,84 +1,407 @@
-package main
-
-import (
-	"fmt"
-	"image"
-	"os"
-	"path/filepath"
-
-	"github.com/gen2brain/go-fitz"
-)
-
-// PDFEngine 封装 PDF 处理功能
-type PDFEngine struct {
-	filePath  string
-	document  *fitz.Document
-	pageCount int
-}
-
-// NewPDFEngine 创建 PDF 引擎实例
-func NewPDFEngine(filePath string) (*PDFEngine, error) {
-	// 检查文件是否存在
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("文件不存在: %s", filePath)
-	}
-
-	// 打开 PDF 文件
-	doc, err := fitz.New(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("无法打开 PDF: %w", err)
-	}
-
-	return &PDFEngine{
-		filePath:  filePath,
-		document:  doc,
-		pageCount: doc.NumPage(),
-	}, nil
-}
-
-// RenderPage 渲染指定页面为图像
-func (e *PDFEngine) RenderPage(pageNum int, dpi int) (image.Image, error) {
-	if pageNum < 1 || pageNum > e.pageCount {
-		return nil, fmt.Errorf("页码超出范围: %d (总页数: %d)", pageNum, e.pageCount)
-	}
-
-	// go-fitz 页码从 0 开始
-	img, err := e.document.ImageDPI(pageNum-1, float64(dpi))
-	if err != nil {
-		return nil, fmt.Errorf("渲染失败: %w", err)
-	}
-
-	return img, nil
-}
-
-// GetPageCount 返回总页数
-func (e *PDFEngine) GetPageCount() int {
-	return e.pageCount
-}
-
-// GetFilePath 返回文件路径
-func (e *PDFEngine) GetFilePath() string {
-	return e.filePath
-}
-
-// GetFileName 返回文件名（不含路径）
-func (e *PDFEngine) GetFileName() string {
-	return filepath.Base(e.filePath)
-}
-
-// GetFileSize 返回文件大小（字节）
-func (e *PDFEngine) GetFileSize() (int64, error) {
-	info, err := os.Stat(e.filePath)
-	if err != nil {
-		return 0, err
-	}
-	return info.Size(), nil
-}
-
-// Close 关闭文档
-func (e *PDFEngine) Close() error {
-	if e.document != nil {
-		return e.document.Close()
-	}
-	return nil
-}
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+// engineSeq 为每个引擎实例分配唯一 ID（用作缓存键）
+var engineSeq uint64
+
+// ErrDocumentClosed 文档已关闭（例如重新加载后仍在使用旧引擎的后台任务）
+var ErrDocumentClosed = errors.New("文档已关闭")
+
+// RenderOptions 渲染选项
+type RenderOptions struct {
+	Rotation int // 顺时针旋转角度，取值 0/90/180/270
+}
+
+// PDFEngine 封装 PDF 处理功能
+type PDFEngine struct {
+	id        uint64 // 缓存键中的文档编号，每次重新排版时更换
+	filePath  string // 文件路径，从内存打开的文档为空
+	name      string // 显示名称
+	data      []byte // 从内存打开的文档内容
+	document  *fitz.Document
+	pageCount int
+	cache     *pageCache
+	password  string         // 打开加密文档时使用的密码
+	encrypted bool           // 文档是否加密
+	format    DocumentFormat // 识别出的文档格式
+	layout    LayoutOptions  // 可重排文档的排版设置
+	links     *linkIndex     // 链接注释索引（首次使用时加载）
+	linksErr  error          // 链接索引加载失败的原因，避免重复解析
+	linksMu   sync.Mutex     // 保护 links 和 linksErr，加载时不占用 mu
+	synctex   *synctexIndex  // SyncTeX 数据（首次使用时加载）
+	mu        sync.Mutex     // 串行化对 MuPDF 句柄的访问，并保护 id、pageCount 和 layout
+}
+
+// NewPDFEngine 创建 PDF 引擎实例
+func NewPDFEngine(filePath string) (*PDFEngine, error) {
+	return NewPDFEngineWithPassword(filePath, "")
+}
+
+// NewPDFEngineWithPassword 使用密码创建 PDF 引擎实例
+// 文档未加密时忽略密码；加密文档未提供密码时返回 ErrPasswordRequired，密码错误时返回 ErrWrongPassword
+func NewPDFEngineWithPassword(filePath, password string) (*PDFEngine, error) {
+	// 检查文件是否存在
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("文件不存在: %s", filePath)
+	}
+
+	// 打开 PDF 文件
+	doc, err := fitz.New(filePath)
+	doc, encrypted, err := unlockDocument(doc, err, password, func() (io.ReadSeeker, error) {
+		data, err := os.ReadFile(filePath)
+		return bytes.NewReader(data), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPDFEngine(doc, filePath, filepath.Base(filePath), nil, password, encrypted), nil
+}
+
+// NewPDFEngineFromBytes 从内存数据创建 PDF 引擎实例，name 用于显示
+func NewPDFEngineFromBytes(name string, data []byte, password string) (*PDFEngine, error) {
+	doc, err := fitz.NewFromMemory(data)
+	doc, encrypted, err := unlockDocument(doc, err, password, func() (io.ReadSeeker, error) {
+		return bytes.NewReader(data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPDFEngine(doc, "", name, data, password, encrypted), nil
+}
+
+// NewPDFEngineFromReader 读取全部数据后创建 PDF 引擎实例（例如标准输入）
+func NewPDFEngineFromReader(name string, r io.Reader, password string) (*PDFEngine, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取文档失败: %w", err)
+	}
+	return NewPDFEngineFromBytes(name, data, password)
+}
+
+// newPDFEngine 组装引擎实例
+func newPDFEngine(doc *fitz.Document, filePath, name string, data []byte, password string, encrypted bool) *PDFEngine {
+	if !encrypted {
+		password = ""
+	}
+	return &PDFEngine{
+		id:        atomic.AddUint64(&engineSeq, 1),
+		filePath:  filePath,
+		name:      name,
+		data:      data,
+		document:  doc,
+		pageCount: doc.NumPage(),
+		cache:     sharedPageCache,
+		password:  password,
+		encrypted: encrypted,
+		format:    detectFormat(name, doc.Metadata()["format"]),
+		layout:    defaultLayout,
+	}
+}
+
+// RenderPage 渲染指定页面为图像
+func (e *PDFEngine) RenderPage(pageNum int, dpi int) (image.Image, error) {
+	return e.RenderPageWithOptions(pageNum, dpi, RenderOptions{})
+}
+
+// RenderPageWithOptions 按渲染选项渲染指定页面，优先使用缓存
+func (e *PDFEngine) RenderPageWithOptions(pageNum int, dpi int, opts RenderOptions) (image.Image, error) {
+	key, err := e.cacheKey(pageNum, dpi, opts)
+	if err != nil {
+		return nil, err
+	}
+	if img, ok := e.cache.Get(key); ok {
+		return img, nil
+	}
+
+	// 查找缓存后可能已重新排版，按渲染时的排版生成缓存键
+	img, docID, err := e.render(pageNum, dpi, opts)
+	if err != nil {
+		return nil, err
+	}
+	key.docID = docID
+	e.cache.Put(key, img)
+	return img, nil
+}
+
+// RenderPageUncached 渲染指定页面但不读写页面缓存
+// 用于缩略图等小图，避免它们挤掉缓存中正文页面的渲染结果
+func (e *PDFEngine) RenderPageUncached(pageNum int, dpi int, opts RenderOptions) (image.Image, error) {
+	img, _, err := e.render(pageNum, dpi, opts)
+	return img, err
+}
+
+// render 渲染指定页面，同时返回渲染时的文档编号
+func (e *PDFEngine) render(pageNum int, dpi int, opts RenderOptions) (image.Image, uint64, error) {
+	e.mu.Lock()
+	if err := e.checkPageLocked(pageNum); err != nil {
+		e.mu.Unlock()
+		return nil, 0, err
+	}
+	docID := e.id
+	rgba, err := e.document.ImageDPI(pageNum-1, float64(dpi)) // go-fitz 页码从 0 开始
+	e.mu.Unlock()
+	if err != nil {
+		return nil, 0, fmt.Errorf("渲染失败: %w", err)
+	}
+	return rotateImage(rgba, opts.Rotation), docID, nil
+}
+
+// PrefetchPage 在后台预渲染页面到缓存，已缓存时直接返回
+func (e *PDFEngine) PrefetchPage(pageNum int, dpi int, opts RenderOptions) error {
+	key, err := e.cacheKey(pageNum, dpi, opts)
+	if err != nil {
+		return err
+	}
+	if e.cache.Contains(key) {
+		return nil
+	}
+
+	_, err = e.RenderPageWithOptions(pageNum, dpi, opts)
+	return err
+}
+
+// cacheKey 检查页码并生成当前排版下的缓存键
+// 每次重新排版都会更换 id，旧排版的渲染结果即使在排版后写入缓存也不会再被读到
+func (e *PDFEngine) cacheKey(pageNum int, dpi int, opts RenderOptions) (pageCacheKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkPageLocked(pageNum); err != nil {
+		return pageCacheKey{}, err
+	}
+	return pageCacheKey{docID: e.id, page: pageNum, dpi: dpi, opts: opts}, nil
+}
+
+// checkPageLocked 检查文档未关闭且页码在范围内（调用方持有 mu）
+// 可重排文档的页数随排版变化，因此页数只能在锁内读取
+func (e *PDFEngine) checkPageLocked(pageNum int) error {
+	if e.document == nil {
+		return ErrDocumentClosed
+	}
+	if pageNum < 1 || pageNum > e.pageCount {
+		return fmt.Errorf("页码超出范围: %d (总页数: %d)", pageNum, e.pageCount)
+	}
+	return nil
+}
+
+// GetPageText 提取指定页面的纯文本
+func (e *PDFEngine) GetPageText(pageNum int) (string, error) {
+	e.mu.Lock()
+	if err := e.checkPageLocked(pageNum); err != nil {
+		e.mu.Unlock()
+		return "", err
+	}
+	text, err := e.document.Text(pageNum - 1)
+	e.mu.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("提取文本失败: %w", err)
+	}
+
+	return text, nil
+}
+
+// OutlineItem 文档目录（书签）条目
+type OutlineItem struct {
+	Level int // 层级，从 1 开始
+	Title string
+	Page  int // 目标页码（从 1 开始），无目标时为 0
+}
+
+// GetOutline 读取文档目录
+func (e *PDFEngine) GetOutline() ([]OutlineItem, error) {
+	e.mu.Lock()
+	if e.document == nil {
+		e.mu.Unlock()
+		return nil, ErrDocumentClosed
+	}
+	toc, err := e.document.ToC()
+	pageCount := e.pageCount
+	e.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("读取目录失败: %w", err)
+	}
+
+	items := make([]OutlineItem, 0, len(toc))
+	for _, entry := range toc {
+		page := entry.Page + 1 // go-fitz 页码从 0 开始
+		if page < 1 || page > pageCount {
+			page = 0
+		}
+		items = append(items, OutlineItem{
+			Level: entry.Level,
+			Title: entry.Title,
+			Page:  page,
+		})
+	}
+	return items, nil
+}
+
+// GetPageCount 返回总页数（可重排文档的页数随排版变化）
+func (e *PDFEngine) GetPageCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pageCount
+}
+
+// GetFilePath 返回文件路径，从内存打开的文档返回空字符串
+func (e *PDFEngine) GetFilePath() string {
+	return e.filePath
+}
+
+// GetFileName 返回文件名（不含路径）
+func (e *PDFEngine) GetFileName() string {
+	return e.name
+}
+
+// GetFormat 返回文档格式
+func (e *PDFEngine) GetFormat() DocumentFormat {
+	return e.format
+}
+
+// GetLayout 返回当前排版设置
+func (e *PDFEngine) GetLayout() LayoutOptions {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.layout
+}
+
+// SetLayout 按新的版面大小和字号重新排版可重排文档，页数可能随之变化
+// 固定版式的格式返回 ErrLayoutUnsupported
+func (e *PDFEngine) SetLayout(opts LayoutOptions) error {
+	if !e.format.Reflowable() {
+		return ErrLayoutUnsupported
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	if e.document == nil {
+		e.mu.Unlock()
+		return ErrDocumentClosed
+	}
+	if err := layoutDocument(e.document, opts); err != nil {
+		e.mu.Unlock()
+		return fmt.Errorf("重新排版失败: %w", err)
+	}
+	oldID := e.id
+	e.id = atomic.AddUint64(&engineSeq, 1)
+	e.pageCount = e.document.NumPage()
+	e.layout = opts
+	e.mu.Unlock()
+
+	// 旧排版的页面缓存已失效
+	e.cache.RemoveDocument(oldID)
+	return nil
+}
+
+// IsEncrypted 返回文档是否加密
+func (e *PDFEngine) IsEncrypted() bool {
+	return e.encrypted
+}
+
+// GetFileSize 返回文件大小（字节）
+func (e *PDFEngine) GetFileSize() (int64, error) {
+	if e.data != nil {
+		return int64(len(e.data)), nil
+	}
+
+	info, err := os.Stat(e.filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// SaveTo 将原始文档内容写入 w（用于另存为）
+func (e *PDFEngine) SaveTo(w io.Writer) error {
+	if e.data != nil {
+		_, err := w.Write(e.data)
+		return err
+	}
+
+	f, err := os.Open(e.filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// openSource 打开原始文档数据，供 pdfcpu 等需要随机读取的库使用
+func (e *PDFEngine) openSource() (io.ReadSeeker, func() error, error) {
+	if e.data != nil {
+		return bytes.NewReader(e.data), func() error { return nil }, nil
+	}
+
+	f, err := os.Open(e.filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// Close 关闭文档
+func (e *PDFEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.cache.RemoveDocument(e.id)
+	if e.document == nil {
+		return nil
+	}
+	doc := e.document
+	e.document = nil
+	return doc.Close()
+}
+
+// rotateImage 按 90 度的倍数顺时针旋转图像
+func rotateImage(src *image.RGBA, rotation int) image.Image {
+	rotation = ((rotation % 360) + 360) % 360
+	if rotation == 0 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	var dst *image.RGBA
+	if rotation == 180 {
+		dst = image.NewRGBA(image.Rect(0, 0, w, h))
+	} else {
+		dst = image.NewRGBA(image.Rect(0, 0, h, w))
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := src.RGBAAt(b.Min.X+x, b.Min.Y+y)
+			switch rotation {
+			case 90:
+				dst.SetRGBA(h-1-y, x, c)
+			case 180:
+				dst.SetRGBA(w-1-x, h-1-y, c)
+			case 270:
+				dst.SetRGBA(y, w-1-x, c)
+			}
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// buildTestFB2 生成包含 paragraphs 段正文的 FB2 电子书
+func buildTestFB2(paragraphs int) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` +
+		`<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">` +
+		`<description><title-info><book-title>Test</book-title></title-info></description><body><section>`)
+	for i := 0; i < paragraphs; i++ {
+		fmt.Fprintf(&b, "<p>Paragraph %d: lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>", i+1)
+	}
+	b.WriteString("</section></body></FictionBook>")
+	return []byte(b.String())
+}
+
+func TestSetLayoutReflowsBook(t *testing.T) {
+	engine, err := NewPDFEngineFromBytes("book.fb2", buildTestFB2(200), "")
+	if err != nil {
+		t.Fatalf("NewPDFEngineFromBytes() = %v", err)
+	}
+	defer engine.Close()
+
+	if got := engine.GetFormat(); got != FormatFB2 {
+		t.Fatalf("GetFormat() = %q, want %q", got, FormatFB2)
+	}
+	if got := engine.GetLayout(); got != defaultLayout {
+		t.Errorf("GetLayout() = %+v, want %+v", got, defaultLayout)
+	}
+
+	before := engine.GetPageCount()
+	if _, err := engine.RenderPage(1, 36); err != nil {
+		t.Fatalf("RenderPage() = %v", err)
+	}
+	oldKey, _ := engine.cacheKey(1, 36, RenderOptions{})
+
+	opts := LayoutOptions{PageWidth: 300, PageHeight: 400, FontSize: 18}
+	if err := engine.SetLayout(opts); err != nil {
+		t.Fatalf("SetLayout() = %v", err)
+	}
+	if got := engine.GetLayout(); got != opts {
+		t.Errorf("GetLayout() = %+v, want %+v", got, opts)
+	}
+	if size, err := engine.GetPageSize(1); err != nil || size.Width != 300 || size.Height != 400 {
+		t.Errorf("GetPageSize(1) = %+v, %v; want 300 × 400", size, err)
+	}
+	if after := engine.GetPageCount(); after <= before {
+		t.Errorf("page count after a smaller page and larger font = %d, want more than %d", after, before)
+	}
+
+	// 新排版使用新的缓存键，旧排版的图像不会再被读到
+	newKey, _ := engine.cacheKey(1, 36, RenderOptions{})
+	if newKey == oldKey {
+		t.Error("cache key did not change after SetLayout")
+	}
+	if engine.cache.Contains(oldKey) {
+		t.Error("old layout is still cached after SetLayout")
+	}
+
+	if err := engine.SetLayout(LayoutOptions{PageWidth: 10, PageHeight: 400, FontSize: 12}); err == nil {
+		t.Error("SetLayout() with a 10pt wide page = nil, want error")
+	}
+}
+
+func TestSetLayoutConcurrentRender(t *testing.T) {
+	engine, err := NewPDFEngineFromBytes("book.fb2", buildTestFB2(100), "")
+	if err != nil {
+		t.Fatalf("NewPDFEngineFromBytes() = %v", err)
+	}
+	defer engine.Close()
+
+	// 渲染、预取和读取页数与重新排版同时进行（配合 -race 检查）
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(dpi int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				engine.RenderPageWithOptions(engine.GetPageCount(), dpi, RenderOptions{})
+				engine.PrefetchPage(1, dpi, RenderOptions{})
+			}
+		}(24 + i)
+	}
+
+	for _, size := range []float64{10, 16, 24, 12} {
+		if err := engine.SetLayout(LayoutOptions{PageWidth: 300, PageHeight: 400, FontSize: size}); err != nil {
+			t.Errorf("SetLayout(font %v) = %v", size, err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestRenderPageUncached(t *testing.T) {
+	engine, err := NewPDFEngineFromBytes("page.pdf", buildTestPDF("/Title (Page)"), "")
+	if err != nil {
+		t.Fatalf("NewPDFEngineFromBytes() = %v", err)
+	}
+	defer engine.Close()
+
+	img, err := engine.RenderPageUncached(1, 72, RenderOptions{Rotation: 90})
+	if err != nil {
+		t.Fatalf("RenderPageUncached() = %v", err)
+	}
+	// 200 × 300 点的页面旋转 90° 后为 300 × 200 像素
+	if got := img.Bounds().Size(); got.X != 300 || got.Y != 200 {
+		t.Errorf("RenderPageUncached() size = %v, want (300,200)", got)
+	}
+	key, _ := engine.cacheKey(1, 72, RenderOptions{Rotation: 90})
+	if engine.cache.Contains(key) {
+		t.Error("RenderPageUncached() stored the page in the page cache")
+	}
+	if _, err := engine.RenderPageUncached(2, 72, RenderOptions{}); err == nil {
+		t.Error("RenderPageUncached(2) on a 1-page document should fail")
+	}
+}
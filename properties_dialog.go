@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// onShowProperties 显示当前文档的属性
+func (ui *ViewerUI) onShowProperties() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		dialog.ShowInformation(ui.tr.DialogPropertiesTitle, ui.tr.StatusNoDocument, ui.window)
+		return
+	}
+
+	engine := currentTab.controller.Engine()
+
+	// 计算 SHA-256 需要读取整个文件，在后台执行
+	go func() {
+		info, err := engine.GetInfo()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(ui.tr.MsgPropertiesFailed, err), ui.window)
+			return
+		}
+		ui.showPropertiesDialog(info)
+	}()
+}
+
+// showPropertiesDialog 以表单形式显示文档属性，并提供复制为 JSON 的按钮
+func (ui *ViewerUI) showPropertiesDialog(info *DocumentInfo) {
+	form := widget.NewForm()
+	for _, field := range info.Fields(ui.tr) {
+		valueLabel := widget.NewLabelWithStyle(field.Value, fyne.TextAlignLeading, fyne.TextStyle{Monospace: field.Monospace})
+		if field.Monospace {
+			valueLabel.Wrapping = fyne.TextWrapBreak
+		} else {
+			valueLabel.Wrapping = fyne.TextWrapWord
+		}
+		form.Append(field.Label, valueLabel)
+	}
+
+	copyBtn := widget.NewButtonWithIcon(ui.tr.ButtonCopyJSON, theme.ContentCopyIcon(), func() {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		ui.window.Clipboard().SetContent(string(data))
+		dialog.ShowInformation(ui.tr.DialogPropertiesTitle, ui.tr.MsgJSONCopied, ui.window)
+	})
+
+	content := container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), copyBtn), nil, nil,
+		container.NewVScroll(form))
+
+	d := dialog.NewCustom(ui.tr.DialogPropertiesTitle, "OK", content, ui.window)
+	d.Resize(fyne.NewSize(560, 600))
+	d.Show()
+}
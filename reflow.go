@@ -0,0 +1,37 @@
+package main
+
+/*
+typedef struct fz_context fz_context;
+typedef struct fz_document fz_document;
+
+// 由 go-fitz 静态链接的 MuPDF 提供
+void fz_layout_document(fz_context *ctx, fz_document *doc, float w, float h, float em);
+*/
+import "C"
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+// errLayoutHandle 无法取得 go-fitz 内部的 MuPDF 句柄（go-fitz 的结构发生了变化）
+var errLayoutHandle = errors.New("无法访问 MuPDF 文档句柄")
+
+// layoutDocument 调用 MuPDF 的 fz_layout_document 按版面大小和字号重新排版可重排文档
+// go-fitz 没有提供这个接口，只能从 Document 的未导出字段 ctx 和 doc 取得 MuPDF 句柄；
+// 调用方需持有串行化 MuPDF 访问的锁，排版后页数和页面尺寸随之改变
+func layoutDocument(doc *fitz.Document, opts LayoutOptions) error {
+	v := reflect.ValueOf(doc).Elem()
+	ctx, handle := v.FieldByName("ctx"), v.FieldByName("doc")
+	if ctx.Kind() != reflect.Pointer || handle.Kind() != reflect.Pointer || ctx.IsNil() || handle.IsNil() {
+		return errLayoutHandle
+	}
+
+	C.fz_layout_document(
+		(*C.fz_context)(ctx.UnsafePointer()),
+		(*C.fz_document)(handle.UnsafePointer()),
+		C.float(opts.PageWidth), C.float(opts.PageHeight), C.float(opts.FontSize))
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"image"
+	"sync"
+	"sync/atomic"
+)
+
+// renderTarget 要渲染的一页及其渲染选项
+type renderTarget struct {
+	page int
+	opts RenderOptions
+}
+
+// renderRequest 单次渲染请求
+type renderRequest struct {
+	ctx        context.Context
+	generation uint64
+	page       int
+	spread     []renderTarget // 双页模式下并排渲染的全部页面，为空时只渲染 page
+	dpi        int
+	opts       RenderOptions
+	prefetch   []renderTarget // 渲染完成后需要预取的页面
+	queued     bool           // 排队的请求（连续模式），不会被更新的请求取代
+	done       func(img image.Image, err error)
+}
+
+// renderWorker 每个文档一个渲染工作协程，串行访问 MuPDF 句柄
+// Submit 的新请求会使旧请求过期，只有最新请求的结果会被交付；
+// Enqueue 排队的请求依次处理，只在各自的 ctx 取消时作废
+type renderWorker struct {
+	engine     DocumentEngine
+	generation uint64 // 最新请求的代号（原子访问）
+
+	mu       sync.Mutex
+	pending  *renderRequest // 等待处理的最新请求
+	queue    []*renderRequest
+	prefetch []renderRequest
+
+	wake    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// newRenderWorker 创建并启动渲染工作协程
+func newRenderWorker(engine DocumentEngine) *renderWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &renderWorker{
+		engine:  engine,
+		wake:    make(chan struct{}, 1),
+		ctx:     ctx,
+		cancel:  cancel,
+		stopped: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Submit 提交渲染请求，之前未完成的请求和预取全部作废
+// targets 包含多页时并排拼接为一张图像
+func (w *renderWorker) Submit(ctx context.Context, targets []renderTarget, dpi int, prefetch []renderTarget, done func(image.Image, error)) {
+	req := &renderRequest{
+		ctx:        ctx,
+		generation: atomic.AddUint64(&w.generation, 1),
+		page:       targets[0].page,
+		dpi:        dpi,
+		opts:       targets[0].opts,
+		prefetch:   prefetch,
+		done:       done,
+	}
+	if len(targets) > 1 {
+		req.spread = targets
+	}
+
+	w.mu.Lock()
+	w.pending = req
+	w.prefetch = nil
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Enqueue 排队渲染一页（连续模式下视口附近的各页），不影响 Submit 的请求和其他排队的请求
+// 处理顺序为 Submit 的请求、排队的请求、预取；ctx 取消后请求被丢弃
+func (w *renderWorker) Enqueue(ctx context.Context, target renderTarget, dpi int, done func(image.Image, error)) {
+	req := &renderRequest{
+		ctx:    ctx,
+		page:   target.page,
+		dpi:    dpi,
+		opts:   target.opts,
+		queued: true,
+		done:   done,
+	}
+
+	w.mu.Lock()
+	w.queue = append(w.queue, req)
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop 停止工作协程并等待当前渲染结束
+func (w *renderWorker) Stop() {
+	w.cancel()
+	<-w.stopped
+}
+
+// run 工作协程主循环：优先处理用户请求，空闲时执行预取
+func (w *renderWorker) run() {
+	defer close(w.stopped)
+
+	for {
+		if w.ctx.Err() != nil {
+			return
+		}
+
+		w.mu.Lock()
+		req := w.pending
+		w.pending = nil
+		if req == nil && len(w.queue) > 0 {
+			req = w.queue[0]
+			w.queue = w.queue[1:]
+		}
+		var job *renderRequest
+		if req == nil && len(w.prefetch) > 0 {
+			job = &w.prefetch[0]
+			w.prefetch = w.prefetch[1:]
+		}
+		w.mu.Unlock()
+
+		switch {
+		case req != nil:
+			w.render(req)
+		case job != nil:
+			if !w.isStale(job) {
+				w.engine.PrefetchPage(job.page, job.dpi, job.opts)
+			}
+		default:
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-w.wake:
+			}
+		}
+	}
+}
+
+// render 处理用户请求，并在结果仍然有效时交付
+func (w *renderWorker) render(req *renderRequest) {
+	if w.isStale(req) {
+		return
+	}
+
+	var img image.Image
+	var err error
+	if len(req.spread) > 1 {
+		img, err = renderSpread(w.engine, req.spread, req.dpi)
+	} else {
+		img, err = w.engine.RenderPageWithOptions(req.page, req.dpi, req.opts)
+	}
+
+	// 渲染期间有更新的请求时丢弃结果
+	if w.isStale(req) {
+		return
+	}
+	req.done(img, err)
+
+	if err != nil || req.queued {
+		return
+	}
+
+	w.mu.Lock()
+	if w.pending == nil {
+		w.prefetch = w.prefetch[:0]
+		for _, target := range req.prefetch {
+			w.prefetch = append(w.prefetch, renderRequest{
+				ctx:        req.ctx,
+				generation: req.generation,
+				page:       target.page,
+				dpi:        req.dpi,
+				opts:       target.opts,
+			})
+		}
+	}
+	w.mu.Unlock()
+}
+
+// isStale 判断请求是否已被取消或被更新的请求取代（排队的请求只会被取消）
+func (w *renderWorker) isStale(req *renderRequest) bool {
+	if w.ctx.Err() != nil || req.ctx.Err() != nil {
+		return true
+	}
+	return !req.queued && req.generation != atomic.LoadUint64(&w.generation)
+}
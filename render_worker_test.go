@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestRenderWorkerDropsStaleResults(t *testing.T) {
+	engine := newFakeEngine(10)
+	engine.started = make(chan int)
+	engine.release = make(chan struct{})
+	w := newRenderWorker(engine)
+	defer w.Stop()
+
+	delivered := make(chan int, 2)
+	submit := func(page int) {
+		w.Submit(context.Background(), []renderTarget{{page: page}}, 72, nil, func(img image.Image, err error) {
+			delivered <- page
+		})
+	}
+
+	// 第 1 页渲染期间提交第 2 页，第 1 页的结果已过期
+	submit(1)
+	if page := <-engine.started; page != 1 {
+		t.Fatalf("worker started page %d, want 1", page)
+	}
+	submit(2)
+	engine.release <- struct{}{}
+
+	if page := <-engine.started; page != 2 {
+		t.Fatalf("worker started page %d, want 2", page)
+	}
+	engine.release <- struct{}{}
+
+	select {
+	case page := <-delivered:
+		if page != 2 {
+			t.Fatalf("done fired for page %d, want only the newest request (page 2)", page)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("done never fired for the newest request")
+	}
+	select {
+	case page := <-delivered:
+		t.Errorf("done fired again for page %d", page)
+	default:
+	}
+}
+
+func TestRenderWorkerSkipsCancelledRequest(t *testing.T) {
+	engine := newFakeEngine(10)
+	w := newRenderWorker(engine)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	delivered := make(chan struct{}, 1)
+	w.Submit(ctx, []renderTarget{{page: 1}}, 72, nil, func(image.Image, error) {
+		delivered <- struct{}{}
+	})
+
+	// 等工作协程处理完请求后停止
+	time.Sleep(50 * time.Millisecond)
+	w.Stop()
+
+	select {
+	case <-delivered:
+		t.Error("done fired for a cancelled request")
+	default:
+	}
+	if pages := engine.renderedPages(); len(pages) != 0 {
+		t.Errorf("cancelled request rendered pages %v, want none", pages)
+	}
+}
+
+func TestRenderWorkerQueue(t *testing.T) {
+	engine := newFakeEngine(10)
+	engine.started = make(chan int)
+	engine.release = make(chan struct{})
+	w := newRenderWorker(engine)
+	defer w.Stop()
+
+	delivered := make(chan int, 5)
+	done := func(page int) func(image.Image, error) {
+		return func(image.Image, error) { delivered <- page }
+	}
+
+	// 第 1 页渲染期间排队第 3、4、5 页（第 4 页随即取消），再提交第 2 页
+	w.Submit(context.Background(), []renderTarget{{page: 1}}, 72, nil, done(1))
+	if page := <-engine.started; page != 1 {
+		t.Fatalf("worker started page %d, want 1", page)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	w.Enqueue(context.Background(), renderTarget{page: 3}, 72, done(3))
+	w.Enqueue(cancelled, renderTarget{page: 4}, 72, done(4))
+	w.Enqueue(context.Background(), renderTarget{page: 5}, 72, done(5))
+	cancel()
+	w.Submit(context.Background(), []renderTarget{{page: 2}}, 72, nil, done(2))
+	engine.release <- struct{}{}
+
+	// Submit 的请求优先，排队的请求不会被它取代，已取消的请求被跳过
+	for _, want := range []int{2, 3, 5} {
+		select {
+		case page := <-engine.started:
+			if page != want {
+				t.Fatalf("worker started page %d, want %d", page, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("worker never started page %d", want)
+		}
+		engine.release <- struct{}{}
+		if page := <-delivered; page != want {
+			t.Fatalf("done fired for page %d, want %d", page, want)
+		}
+	}
+
+	select {
+	case page := <-delivered:
+		t.Errorf("done fired again for page %d", page)
+	case page := <-engine.started:
+		t.Errorf("worker started page %d after the queue was drained", page)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
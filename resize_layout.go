@@ -0,0 +1,31 @@
+package main
+
+import "fyne.io/fyne/v2"
+
+// resizeLayout 与 Stack 布局相同，容器尺寸变化时回调（用于自动适配缩放）
+type resizeLayout struct {
+	size     fyne.Size
+	onResize func(size fyne.Size)
+}
+
+func (l *resizeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, obj := range objects {
+		obj.Resize(size)
+		obj.Move(fyne.NewPos(0, 0))
+	}
+
+	if size != l.size {
+		l.size = size
+		if l.onResize != nil {
+			l.onResize(size)
+		}
+	}
+}
+
+func (l *resizeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var size fyne.Size
+	for _, obj := range objects {
+		size = size.Max(obj.MinSize())
+	}
+	return size
+}
@@ -0,0 +1,57 @@
+package main
+
+// normalizeRotation 将角度规范为 0/90/180/270
+func normalizeRotation(rotation int) int {
+	return ((rotation % 360) + 360) % 360
+}
+
+// rotatedSize 返回按顺时针旋转后的页面尺寸
+func rotatedSize(size PageSize, rotation int) PageSize {
+	switch normalizeRotation(rotation) {
+	case 90, 270:
+		size.Width, size.Height = size.Height, size.Width
+	}
+	return size
+}
+
+// pagePlacement 页面在显示图像中的位置和旋转
+type pagePlacement struct {
+	X        float64  // 页面左边缘在图像中的横向偏移（点）
+	Size     PageSize // 未旋转的页面尺寸（点）
+	Rotation int      // 顺时针旋转角度
+}
+
+// Transform 将页面坐标中的矩形换算为显示图像中的矩形
+func (p pagePlacement) Transform(r PageRect) PageRect {
+	w, h := p.Size.Width, p.Size.Height
+	switch normalizeRotation(p.Rotation) {
+	case 90:
+		r = PageRect{X: h - r.Y - r.H, Y: r.X, W: r.H, H: r.W}
+	case 180:
+		r = PageRect{X: w - r.X - r.W, Y: h - r.Y - r.H, W: r.W, H: r.H}
+	case 270:
+		r = PageRect{X: r.Y, Y: w - r.X - r.W, W: r.H, H: r.W}
+	}
+	r.X += p.X
+	return r
+}
+
+// PagePoint 将显示图像中的点换算为页面坐标，点不在该页上时返回 false
+func (p pagePlacement) PagePoint(x, y float64) (float64, float64, bool) {
+	w, h := p.Size.Width, p.Size.Height
+	shown := rotatedSize(p.Size, p.Rotation)
+	x -= p.X
+	if x < 0 || y < 0 || x > shown.Width || y > shown.Height {
+		return 0, 0, false
+	}
+
+	switch normalizeRotation(p.Rotation) {
+	case 90:
+		return y, h - x, true
+	case 180:
+		return w - x, h - y, true
+	case 270:
+		return w - y, x, true
+	}
+	return x, y, true
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestPagePlacementTransform(t *testing.T) {
+	size := PageSize{Width: 100, Height: 200}
+	rect := PageRect{X: 10, Y: 20, W: 30, H: 40}
+
+	tests := []struct {
+		rotation int
+		want     PageRect
+	}{
+		{rotation: 0, want: PageRect{X: 10, Y: 20, W: 30, H: 40}},
+		{rotation: 90, want: PageRect{X: 140, Y: 10, W: 40, H: 30}},
+		{rotation: 180, want: PageRect{X: 60, Y: 140, W: 30, H: 40}},
+		{rotation: 270, want: PageRect{X: 20, Y: 60, W: 40, H: 30}},
+		{rotation: -90, want: PageRect{X: 20, Y: 60, W: 40, H: 30}},
+	}
+
+	for _, tt := range tests {
+		p := pagePlacement{Size: size, Rotation: tt.rotation}
+		if got := p.Transform(rect); got != tt.want {
+			t.Errorf("Transform() with rotation %d = %+v, want %+v", tt.rotation, got, tt.want)
+		}
+	}
+
+	shifted := pagePlacement{X: 50, Size: size}
+	if got := shifted.Transform(rect); got.X != 60 {
+		t.Errorf("Transform() with offset X=50 gave X=%v, want 60", got.X)
+	}
+}
+
+func TestPagePlacementPagePoint(t *testing.T) {
+	size := PageSize{Width: 200, Height: 300}
+	for _, rotation := range []int{0, 90, 180, 270} {
+		p := pagePlacement{X: 50, Size: size, Rotation: rotation}
+		// 页面上的点经 Transform 显示后，PagePoint 应换算回原位置
+		shown := p.Transform(PageRect{X: 30, Y: 70})
+		x, y, ok := p.PagePoint(shown.X, shown.Y)
+		if !ok || x != 30 || y != 70 {
+			t.Errorf("rotation %d: PagePoint(%v, %v) = %v, %v, %v; want 30, 70, true", rotation, shown.X, shown.Y, x, y, ok)
+		}
+	}
+
+	p := pagePlacement{X: 50, Size: size}
+	if _, _, ok := p.PagePoint(10, 10); ok {
+		t.Error("PagePoint() left of the page should fail")
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchOptions 搜索选项
+type SearchOptions struct {
+	CaseSensitive bool
+	WholeWord     bool
+	Regex         bool
+}
+
+// SearchHit 一个搜索结果
+type SearchHit struct {
+	Page int
+	Rect PageRect
+	Line string // 命中所在行的文本
+}
+
+// searchPattern 编译后的搜索词
+// 全字匹配不使用正则表达式的 \b：它只认 ASCII 字母和数字，中文和带重音的文字永远匹配不到
+type searchPattern struct {
+	re        *regexp.Regexp
+	wholeWord bool
+}
+
+// compileSearch 根据选项编译搜索词
+func compileSearch(query string, opts SearchOptions) (*searchPattern, error) {
+	pattern := query
+	if !opts.Regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !opts.CaseSensitive {
+		pattern = `(?i)` + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &searchPattern{re: re, wholeWord: opts.WholeWord}, nil
+}
+
+// FindAllIndex 返回 s 中全部非空匹配的字节范围，全字匹配时去掉词中间的匹配
+func (p *searchPattern) FindAllIndex(s string) [][]int {
+	var out [][]int
+	for _, loc := range p.re.FindAllStringIndex(s, -1) {
+		if loc[0] == loc[1] {
+			continue // 忽略空匹配
+		}
+		if p.wholeWord && (!isWordBoundary(s, loc[0]) || !isWordBoundary(s, loc[1])) {
+			continue
+		}
+		out = append(out, loc)
+	}
+	return out
+}
+
+// MatchString 判断 s 中是否有匹配
+func (p *searchPattern) MatchString(s string) bool {
+	if !p.wholeWord {
+		return p.re.MatchString(s)
+	}
+	return len(p.FindAllIndex(s)) > 0
+}
+
+// isWordBoundary 判断字节位置 i 两侧的字符是否属于不同的词
+// 中日韩文字不用空格分词，每个字都视为一个词
+func isWordBoundary(s string, i int) bool {
+	if i == 0 || i == len(s) {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	return !isWordRune(before) || !isWordRune(after) || isCJK(before) || isCJK(after)
+}
+
+// isWordRune 判断字符是否属于单词（任意语言的字母、数字、组合符号和下划线）
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// Search 在整个文档中搜索，按页码顺序返回命中结果
+func (e *PDFEngine) Search(ctx context.Context, pattern *searchPattern) ([]SearchHit, error) {
+	var hits []SearchHit
+	pageCount := e.GetPageCount()
+	for page := 1; page <= pageCount; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lines, err := e.GetPageLines(page)
+		if err != nil {
+			return nil, err
+		}
+
+		var glyphs []textGlyph
+		glyphsLoaded := false
+		for _, line := range lines {
+			matches := pattern.FindAllIndex(line.Text)
+			if len(matches) == 0 {
+				continue
+			}
+
+			// 只为有命中的页面读取字形位置，读取失败时按字号估算
+			if !glyphsLoaded {
+				glyphs, _ = e.GetPageGlyphs(page)
+				glyphsLoaded = true
+			}
+			line.locateChars(glyphs)
+
+			for _, loc := range matches {
+				start := utf8.RuneCountInString(line.Text[:loc[0]])
+				end := start + utf8.RuneCountInString(line.Text[loc[0]:loc[1]])
+				hits = append(hits, SearchHit{
+					Page: page,
+					Rect: line.CharRect(start, end),
+					Line: line.Text,
+				})
+			}
+		}
+	}
+	return hits, nil
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// searchState 标签页的搜索状态
+type searchState struct {
+	query   string
+	opts    SearchOptions
+	hits    []SearchHit
+	index   int  // 当前命中下标，-1 表示尚未定位
+	running bool // 是否正在搜索
+	cancel  context.CancelFunc
+}
+
+// searchEntry 搜索输入框：Enter 查找下一个，Shift+Enter 查找上一个
+type searchEntry struct {
+	widget.Entry
+	shift    bool
+	onSearch func(forward bool)
+}
+
+func newSearchEntry(onSearch func(forward bool)) *searchEntry {
+	e := &searchEntry{onSearch: onSearch}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+func (e *searchEntry) KeyDown(key *fyne.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		e.shift = true
+	}
+	e.Entry.KeyDown(key)
+}
+
+func (e *searchEntry) KeyUp(key *fyne.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		e.shift = false
+	}
+	e.Entry.KeyUp(key)
+}
+
+func (e *searchEntry) TypedKey(key *fyne.KeyEvent) {
+	if key.Name == fyne.KeyReturn || key.Name == fyne.KeyEnter {
+		if e.onSearch != nil {
+			e.onSearch(!e.shift)
+		}
+		return
+	}
+	e.Entry.TypedKey(key)
+}
+
+// createSearchBar 创建搜索栏（默认隐藏）
+func (ui *ViewerUI) createSearchBar() fyne.CanvasObject {
+	ui.searchEntry = newSearchEntry(ui.onSearch)
+	ui.searchEntry.SetPlaceHolder(ui.tr.HintSearch)
+	ui.searchEntry.OnChanged = func(string) { ui.resetSearch() }
+
+	onOption := func(bool) { ui.resetSearch() }
+	ui.searchCase = widget.NewCheck(ui.tr.SearchCaseSensitive, onOption)
+	ui.searchWord = widget.NewCheck(ui.tr.SearchWholeWord, onOption)
+	ui.searchRegex = widget.NewCheck(ui.tr.SearchRegex, onOption)
+
+	prevBtn := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { ui.onSearch(false) })
+	nextBtn := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { ui.onSearch(true) })
+	closeBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), ui.hideSearchBar)
+
+	ui.searchBar = container.NewBorder(
+		nil, nil,
+		nil,
+		container.NewHBox(ui.searchCase, ui.searchWord, ui.searchRegex, prevBtn, nextBtn, closeBtn),
+		ui.searchEntry,
+	)
+	ui.searchBar.Hide()
+	return ui.searchBar
+}
+
+// showSearchBar 显示搜索栏并聚焦输入框
+func (ui *ViewerUI) showSearchBar() {
+	ui.searchBar.Show()
+	ui.window.Canvas().Focus(ui.searchEntry)
+}
+
+// hideSearchBar 隐藏搜索栏并清除当前标签页的高亮
+func (ui *ViewerUI) hideSearchBar() {
+	ui.searchBar.Hide()
+	ui.resetSearch()
+}
+
+// currentSearchOptions 读取搜索栏上的选项
+func (ui *ViewerUI) currentSearchOptions() SearchOptions {
+	return SearchOptions{
+		CaseSensitive: ui.searchCase.Checked,
+		WholeWord:     ui.searchWord.Checked,
+		Regex:         ui.searchRegex.Checked,
+	}
+}
+
+// resetSearch 搜索词或选项改变时清除当前标签页的搜索结果
+func (ui *ViewerUI) resetSearch() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+	currentTab.clearSearch()
+	ui.updateStatusBar()
+}
+
+// onSearch 跳到下一个（forward）或上一个命中，必要时先执行搜索
+func (ui *ViewerUI) onSearch(forward bool) {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		return
+	}
+
+	query := ui.searchEntry.Text
+	if query == "" {
+		return
+	}
+
+	opts := ui.currentSearchOptions()
+	state := currentTab.search
+	if state != nil && state.query == query && state.opts == opts {
+		if !state.running {
+			currentTab.stepSearch(ui, forward)
+		}
+		return
+	}
+
+	pattern, err := compileSearch(query, opts)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgSearchFailed, err), ui.window)
+		return
+	}
+
+	currentTab.clearSearch()
+	ctx, cancel := context.WithCancel(context.Background())
+	state = &searchState{query: query, opts: opts, index: -1, running: true, cancel: cancel}
+	currentTab.search = state
+	ui.updateStatusBar()
+
+	// 搜索全部页面可能较慢，在后台执行；搜索状态只在界面协程中读写，结果交回界面协程发布
+	engine := currentTab.controller.Engine()
+	go func() {
+		hits, err := engine.Search(ctx, pattern)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return // 已被新的搜索取代
+			}
+			state.running = false
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(ui.tr.MsgSearchFailed, err), ui.window)
+				currentTab.clearSearch()
+				ui.updateStatusBar()
+				return
+			}
+
+			state.hits = hits
+			currentTab.stepSearch(ui, forward)
+		})
+	}()
+}
+
+// stepSearch 在命中结果之间移动，首次定位时从当前页开始
+func (tab *PDFTab) stepSearch(ui *ViewerUI, forward bool) {
+	state := tab.search
+	if len(state.hits) == 0 {
+		ui.updateStatusBar()
+		return
+	}
+
+	n := len(state.hits)
+	switch {
+	case state.index >= 0 && forward:
+		state.index = (state.index + 1) % n
+	case state.index >= 0:
+		state.index = (state.index - 1 + n) % n
+	default:
+		state.index = firstHitFrom(state.hits, tab.controller.GetCurrentPage(), forward)
+	}
+
+	hit := state.hits[state.index]
+	if rect, shown := tab.imageRect(hit.Page, hit.Rect); shown {
+		tab.updateOverlay()
+		tab.scrollToRect(rect)
+	} else {
+		// 新页面渲染完成后再滚动到命中位置
+		tab.scrollTarget = &hit
+		if err := tab.controller.GoToPage(hit.Page); err == nil {
+			tab.renderPage(ui)
+		}
+	}
+	ui.updateStatusBar()
+}
+
+// firstHitFrom 返回从指定页开始向前或向后的第一个命中下标
+func firstHitFrom(hits []SearchHit, page int, forward bool) int {
+	if forward {
+		for i, hit := range hits {
+			if hit.Page >= page {
+				return i
+			}
+		}
+		return 0
+	}
+
+	for i := len(hits) - 1; i >= 0; i-- {
+		if hits[i].Page <= page {
+			return i
+		}
+	}
+	return len(hits) - 1
+}
+
+// clearSearch 取消搜索并清除高亮
+func (tab *PDFTab) clearSearch() {
+	if tab.search != nil && tab.search.cancel != nil {
+		tab.search.cancel()
+	}
+	tab.search = nil
+	tab.updateOverlay()
+}
+
+// searchHighlights 返回显示页上的搜索高亮，imageRect 将页面坐标换算为图像坐标
+func (tab *PDFTab) searchHighlights(imageRect func(page int, rect PageRect) (PageRect, bool)) []overlayRect {
+	state := tab.search
+	if state == nil {
+		return nil
+	}
+
+	var rects []overlayRect
+	for i, hit := range state.hits {
+		rect, shown := imageRect(hit.Page, hit.Rect)
+		if !shown {
+			continue
+		}
+		c := highlightColor
+		if i == state.index {
+			c = currentHighlightColor
+		}
+		rects = append(rects, overlayRect{rect: rect, color: c})
+	}
+	return rects
+}
+
+// searchStatusText 返回状态栏上的搜索进度文本
+func (tab *PDFTab) searchStatusText(tr *Translations) string {
+	state := tab.search
+	switch {
+	case state == nil:
+		return ""
+	case state.running:
+		return tr.StatusSearching
+	case len(state.hits) == 0:
+		return tr.StatusNoMatches
+	default:
+		return fmt.Sprintf(tr.StatusSearchHit, state.index+1, len(state.hits))
+	}
+}
+
+// scrollToRect 滚动视图使图像上的矩形可见
+func (tab *PDFTab) scrollToRect(rect PageRect) {
+	pos := tab.overlay.PagePointToPosition(rect.X, rect.Y)
+	viewport := tab.scrollView.Size()
+
+	offset := fyne.NewPos(pos.X-viewport.Width/3, pos.Y-viewport.Height/3)
+	if offset.X < 0 {
+		offset.X = 0
+	}
+	if offset.Y < 0 {
+		offset.Y = 0
+	}
+	tab.scrollView.Offset = offset
+	tab.scrollView.Refresh()
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestSearchWholeWordUnicode(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        int
+	}{
+		{"café", "un café noir", 1},
+		{"café", "deux cafés", 0},
+		{"中文", "我们说中文吧", 1},
+		{"PDF", "(PDF) files", 1},
+		{"word", "a word_x", 0},
+		{"Straße", "die Straße, bitte", 1},
+	}
+	for _, tt := range tests {
+		pattern, err := compileSearch(tt.query, SearchOptions{WholeWord: true})
+		if err != nil {
+			t.Fatalf("compileSearch(%q) = %v", tt.query, err)
+		}
+		if got := len(pattern.FindAllIndex(tt.text)); got != tt.want {
+			t.Errorf("whole-word %q in %q: %d matches, want %d", tt.query, tt.text, got, tt.want)
+		}
+		if got := pattern.MatchString(tt.text); got != (tt.want > 0) {
+			t.Errorf("MatchString(%q) for %q = %v", tt.text, tt.query, got)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// instanceDialTimeout 连接正在运行的实例时的超时，超时按没有实例处理
+const instanceDialTimeout = time.Second
+
+// instanceReplyTimeout 等待正在运行的实例加载完文档并答复的最长时间（远程 URI 可能需要下载）
+const instanceReplyTimeout = time.Minute
+
+// openRequest 命令行要求打开的文档和打开后的操作
+// 第二个进程通过套接字把它转交给正在运行的实例，字段与命令行参数一一对应
+type openRequest struct {
+	File     string `json:"file,omitempty"`     // 文件路径（绝对路径）或 URI，为空时只激活窗口
+	Password string `json:"password,omitempty"` // 打开加密文档时使用的密码
+	Page     int    `json:"page,omitempty"`     // 打开后跳转到的页码
+	Search   string `json:"search,omitempty"`   // 打开后搜索的文本
+	Forward  string `json:"forward,omitempty"`  // SyncTeX 正向搜索的源文件位置 file:line
+}
+
+// openResponse 运行中的实例对请求的答复
+type openResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// buildOpenRequest 校验命令行参数并生成打开请求
+// 本地文件和正向搜索的源文件转换为绝对路径，交给工作目录不同的实例时仍然有效
+func buildOpenRequest(arg, password string, page int, search, forward string) (openRequest, error) {
+	req := openRequest{File: arg, Password: password, Page: page, Search: search}
+	if page < 0 {
+		return req, fmt.Errorf("无效的页码: %d", page)
+	}
+	if arg == "" && (page > 0 || search != "" || forward != "") {
+		return req, fmt.Errorf("--page、--search 和 --forward 需要同时指定文档")
+	}
+	if arg != "" && arg != stdinArg {
+		if _, err := os.Stat(arg); err == nil {
+			if abs, err := filepath.Abs(arg); err == nil {
+				req.File = abs
+			}
+		}
+	}
+
+	if forward != "" {
+		loc, err := ParseSourceLocation(forward)
+		if err != nil {
+			return req, err
+		}
+		if abs, err := filepath.Abs(loc.File); err == nil {
+			loc.File = abs
+		}
+		req.Forward = loc.String()
+	}
+	return req, nil
+}
+
+// instanceSocketPath 返回当前用户的实例套接字路径
+// 优先使用 XDG_RUNTIME_DIR（仅当前用户可访问），否则在临时目录下创建仅当前用户可访问的目录
+func instanceSocketPath() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "pdfviewer.sock"), nil
+	}
+
+	dir := filepath.Join(os.TempDir(), "pdfviewer-"+strconv.Itoa(os.Getuid()))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("创建实例目录失败: %w", err)
+	}
+	return filepath.Join(dir, "instance.sock"), nil
+}
+
+// sendOpenRequest 把请求交给正在运行的实例
+// 没有正在运行的实例时返回 false 和 nil 错误，调用方应自己启动界面
+func sendOpenRequest(req openRequest) (bool, error) {
+	path, err := instanceSocketPath()
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := net.DialTimeout("unix", path, instanceDialTimeout)
+	if err != nil {
+		return false, nil // 套接字不存在或已失效
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(instanceDialTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return false, fmt.Errorf("发送请求失败: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(instanceReplyTimeout))
+	var resp openResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return false, fmt.Errorf("读取答复失败: %w", err)
+	}
+	if resp.Error != "" {
+		return true, errors.New(resp.Error)
+	}
+	return true, nil
+}
+
+// instanceServer 接收其他进程转交的打开请求
+type instanceServer struct {
+	listener net.Listener
+	handle   func(req openRequest) error
+}
+
+// listenOpenRequests 在实例套接字上监听，handle 在后台协程中调用
+// 残留的套接字文件（上一个实例异常退出）会被删除后重新监听
+func listenOpenRequests(handle func(req openRequest) error) (*instanceServer, error) {
+	path, err := instanceSocketPath()
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		if conn, dialErr := net.DialTimeout("unix", path, instanceDialTimeout); dialErr == nil {
+			conn.Close()
+			return nil, fmt.Errorf("已有实例在监听 %s", path)
+		}
+		os.Remove(path)
+		if listener, err = net.Listen("unix", path); err != nil {
+			return nil, fmt.Errorf("监听 %s 失败: %w", path, err)
+		}
+	}
+
+	s := &instanceServer{listener: listener, handle: handle}
+	go s.serve()
+	return s, nil
+}
+
+// serve 依次处理连接（请求会修改界面，不并发处理），直到监听关闭
+func (s *instanceServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.serveConn(conn)
+	}
+}
+
+// serveConn 读取一个请求，等处理完成（文档加载结束）后答复结果
+func (s *instanceServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(instanceDialTimeout))
+
+	var req openRequest
+	var resp openResponse
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		resp.Error = fmt.Sprintf("无效的请求: %v", err)
+	} else if err := s.handle(req); err != nil {
+		resp.Error = err.Error()
+	}
+	conn.SetDeadline(time.Now().Add(instanceDialTimeout))
+	json.NewEncoder(conn).Encode(resp)
+}
+
+// Close 停止监听并删除套接字文件
+func (s *instanceServer) Close() error {
+	return s.listener.Close()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildOpenRequest(t *testing.T) {
+	// 相对路径按当前目录解析（macOS 的临时目录是符号链接，先解析为真实路径）
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "paper.pdf")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	req, err := buildOpenRequest("paper.pdf", "", 3, "lemma", "sec/intro.tex:12")
+	if err != nil {
+		t.Fatalf("buildOpenRequest() = %v", err)
+	}
+	want := openRequest{
+		File:    file,
+		Page:    3,
+		Search:  "lemma",
+		Forward: filepath.Join(dir, "sec", "intro.tex") + ":12",
+	}
+	if req != want {
+		t.Errorf("buildOpenRequest() = %+v, want %+v", req, want)
+	}
+
+	// URI 和标准输入原样保留
+	for _, arg := range []string{"https://example.com/a.pdf", stdinArg} {
+		if req, err := buildOpenRequest(arg, "", 0, "", ""); err != nil || req.File != arg {
+			t.Errorf("buildOpenRequest(%q) = %+v, %v", arg, req, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		arg, forward string
+		page         int
+	}{
+		{arg: "", page: 2},
+		{arg: "paper.pdf", page: -1},
+		{arg: "paper.pdf", forward: "intro.tex"},
+	} {
+		if _, err := buildOpenRequest(tt.arg, "", tt.page, "", tt.forward); err == nil {
+			t.Errorf("buildOpenRequest(%q, page %d, forward %q) should fail", tt.arg, tt.page, tt.forward)
+		}
+	}
+}
+
+func TestSingleInstanceHandOff(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	// 没有实例在运行时由调用方自己启动
+	if sent, err := sendOpenRequest(openRequest{File: "/tmp/a.pdf"}); sent || err != nil {
+		t.Fatalf("sendOpenRequest() without a running instance = %v, %v; want false, nil", sent, err)
+	}
+
+	received := make(chan openRequest, 1)
+	server, err := listenOpenRequests(func(req openRequest) error {
+		if req.Page > 100 {
+			return errors.New("page out of range")
+		}
+		received <- req
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("listenOpenRequests() = %v", err)
+	}
+	defer server.Close()
+
+	if _, err := listenOpenRequests(func(openRequest) error { return nil }); err == nil {
+		t.Error("a second listener should fail while the first instance is running")
+	}
+
+	want := openRequest{File: "/tmp/a.pdf", Page: 4, Search: "lemma"}
+	if sent, err := sendOpenRequest(want); !sent || err != nil {
+		t.Fatalf("sendOpenRequest() = %v, %v; want true, nil", sent, err)
+	}
+	if got := <-received; got != want {
+		t.Errorf("running instance received %+v, want %+v", got, want)
+	}
+
+	if sent, err := sendOpenRequest(openRequest{File: "/tmp/a.pdf", Page: 200}); !sent || err == nil {
+		t.Errorf("sendOpenRequest() rejected by the instance = %v, %v; want true and an error", sent, err)
+	}
+}
+
+func TestSingleInstanceStaleSocket(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	path, err := instanceSocketPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 上一个实例异常退出后留下的套接字文件
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server, err := listenOpenRequests(func(openRequest) error { return nil })
+	if err != nil {
+		t.Fatalf("listenOpenRequests() with a stale socket = %v", err)
+	}
+	server.Close()
+}
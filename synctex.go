@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrNoSyncTeX 文档旁边没有 SyncTeX 文件（或文档不是从本地文件打开的）
+var ErrNoSyncTeX = errors.New("找不到 SyncTeX 文件")
+
+// spPerBP 每个 PostScript 点（bp）对应的 TeX sp 数：65536 × 72.27 / 72
+const spPerBP = 65781.76
+
+// SourceLocation 源文件中的一行
+type SourceLocation struct {
+	File string // 源文件的绝对路径
+	Line int
+}
+
+// String 返回 file:line 形式
+func (l SourceLocation) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// ParseSourceLocation 解析 file:line 形式的源文件位置（文件名本身可以含冒号，例如 Windows 盘符）
+func ParseSourceLocation(s string) (SourceLocation, error) {
+	i := strings.LastIndex(s, ":")
+	if i <= 0 {
+		return SourceLocation{}, fmt.Errorf("源文件位置格式应为 file:line: %s", s)
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil || line < 1 {
+		return SourceLocation{}, fmt.Errorf("无效的行号: %s", s[i+1:])
+	}
+	return SourceLocation{File: s[:i], Line: line}, nil
+}
+
+// SyncBox 源文件中的一行在 PDF 页面上对应的区域
+type SyncBox struct {
+	Page int
+	Rect PageRect // 页面坐标（点），左上角为原点
+}
+
+// synctexNode SyncTeX 记录的一个节点，坐标已换算为点
+type synctexNode struct {
+	kind    byte // '(' 水平盒子、'[' 垂直盒子、'h'/'v' 空盒子、'k' kern、'g' glue、'$' 公式、'x' 当前位置
+	input   int  // 源文件编号
+	line    int
+	x, y    float64 // 左端和基线位置
+	w, h, d float64 // 宽度、高度和深度
+	parent  int     // 所在盒子在同一页节点中的下标，-1 表示位于页面顶层
+}
+
+// isHBox 是否为水平盒子（对应一行排版结果）
+func (n *synctexNode) isHBox() bool {
+	return n.kind == '(' || n.kind == 'h'
+}
+
+// rect 节点覆盖的区域，点状节点返回宽高为 0 的矩形
+func (n *synctexNode) rect() PageRect {
+	r := PageRect{X: n.x, Y: n.y - n.h, W: n.w, H: n.h + n.d}
+	if r.W < 0 { // 从右向左排版的盒子宽度为负
+		r.X += r.W
+		r.W = -r.W
+	}
+	return r
+}
+
+// distance 页面上的点到节点区域的距离
+func (n *synctexNode) distance(x, y float64) float64 {
+	r := n.rect()
+	dx := math.Max(0, math.Max(r.X-x, x-r.X-r.W))
+	dy := math.Max(0, math.Max(r.Y-y, y-r.Y-r.H))
+	return math.Hypot(dx, dy)
+}
+
+// synctexIndex 解析后的 SyncTeX 数据
+type synctexIndex struct {
+	inputs map[int]string // 源文件编号 → 绝对路径
+	pages  map[int][]synctexNode
+}
+
+// synctexPath 返回 PDF 旁边的 SyncTeX 文件路径，优先使用压缩格式
+func synctexPath(pdfPath string) (string, bool) {
+	base := strings.TrimSuffix(pdfPath, filepath.Ext(pdfPath))
+	for _, ext := range []string{".synctex.gz", ".synctex"} {
+		if _, err := os.Stat(base + ext); err == nil {
+			return base + ext, true
+		}
+	}
+	return "", false
+}
+
+// loadSyncTeX 读取 PDF 旁边的 SyncTeX 文件，相对路径的源文件按 PDF 所在目录解析
+func loadSyncTeX(pdfPath string) (*synctexIndex, error) {
+	path, ok := synctexPath(pdfPath)
+	if !ok {
+		return nil, ErrNoSyncTeX
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取 SyncTeX 文件失败: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("解压 SyncTeX 文件失败: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	idx, err := parseSyncTeX(r, filepath.Dir(pdfPath))
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", filepath.Base(path), err)
+	}
+	return idx, nil
+}
+
+// parseSyncTeX 解析 SyncTeX 文本格式
+// 坐标单位为 Unit 个 sp，乘以放大倍数后换算为点；Post scriptum 中的覆盖设置被忽略
+func parseSyncTeX(r io.Reader, dir string) (*synctexIndex, error) {
+	idx := &synctexIndex{inputs: map[int]string{}, pages: map[int][]synctexNode{}}
+
+	unit, magnification := 1.0, 1000.0
+	var xOffset, yOffset float64
+	var scale float64 // 内容开始后确定
+
+	page := 0
+	var stack []int // 当前页上未结束的盒子
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "Input:"):
+			fields := strings.SplitN(line[len("Input:"):], ":", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			tag, err := strconv.Atoi(fields[0])
+			if err != nil {
+				continue
+			}
+			path := fields[1]
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			idx.inputs[tag] = filepath.Clean(path)
+			continue
+		case strings.HasPrefix(line, "Unit:"):
+			unit = parseSyncTeXFloat(line[len("Unit:"):], unit)
+			continue
+		case strings.HasPrefix(line, "Magnification:"):
+			magnification = parseSyncTeXFloat(line[len("Magnification:"):], magnification)
+			continue
+		case strings.HasPrefix(line, "X Offset:"):
+			xOffset = parseSyncTeXFloat(line[len("X Offset:"):], 0)
+			continue
+		case strings.HasPrefix(line, "Y Offset:"):
+			yOffset = parseSyncTeXFloat(line[len("Y Offset:"):], 0)
+			continue
+		case strings.HasPrefix(line, "Content:"):
+			scale = unit * magnification / 1000 / spPerBP
+			continue
+		case strings.HasPrefix(line, "Postamble:"):
+			return idx, scanner.Err()
+		}
+		if scale == 0 {
+			continue // 前言中的其他字段
+		}
+
+		switch kind := line[0]; kind {
+		case '{':
+			page, _ = strconv.Atoi(line[1:])
+			stack = stack[:0]
+		case '}':
+			page = 0
+		case ')', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case '(', '[', 'h', 'v', 'k', 'g', '$', 'x':
+			if page == 0 {
+				continue
+			}
+			node, err := parseSyncTeXNode(kind, line[1:])
+			if err != nil {
+				continue // 跳过无法识别的记录
+			}
+			node.x = node.x*scale + xOffset*scale
+			node.y = node.y*scale + yOffset*scale
+			node.w *= scale
+			node.h *= scale
+			node.d *= scale
+			node.parent = -1
+			if len(stack) > 0 {
+				node.parent = stack[len(stack)-1]
+			}
+
+			idx.pages[page] = append(idx.pages[page], node)
+			if kind == '(' || kind == '[' {
+				stack = append(stack, len(idx.pages[page])-1)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// parseSyncTeXNode 解析节点记录 tag,line[,column]:x,y[:W[,H,D]]，坐标保持原始单位
+func parseSyncTeXNode(kind byte, s string) (synctexNode, error) {
+	node := synctexNode{kind: kind}
+	fields := strings.Split(s, ":")
+	if len(fields) < 2 {
+		return node, fmt.Errorf("无效的 SyncTeX 记录: %c%s", kind, s)
+	}
+
+	var err error
+	link := strings.Split(fields[0], ",")
+	if len(link) < 2 {
+		return node, fmt.Errorf("无效的 SyncTeX 记录: %c%s", kind, s)
+	}
+	if node.input, err = strconv.Atoi(link[0]); err != nil {
+		return node, fmt.Errorf("无效的 SyncTeX 记录: %c%s", kind, s)
+	}
+	if node.line, err = strconv.Atoi(link[1]); err != nil {
+		return node, fmt.Errorf("无效的 SyncTeX 记录: %c%s", kind, s)
+	}
+
+	values := strings.Split(fields[1], ",")
+	if len(fields) > 2 {
+		values = append(values, strings.Split(fields[2], ",")...)
+	}
+	dims := []*float64{&node.x, &node.y, &node.w, &node.h, &node.d}
+	for i, v := range values {
+		if i >= len(dims) {
+			break
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return node, fmt.Errorf("无效的 SyncTeX 记录: %c%s", kind, s)
+		}
+		*dims[i] = float64(n)
+	}
+	return node, nil
+}
+
+// parseSyncTeXFloat 解析前言中的数值，无效时返回默认值
+func parseSyncTeXFloat(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// Inverse 反向搜索：返回页面上某点对应的源文件位置
+// 优先取包含该点的最小水平盒子（一行）中横向最近的节点，点不在任何一行上时取页面上最近的节点
+func (idx *synctexIndex) Inverse(page int, x, y float64) (SourceLocation, bool) {
+	nodes := idx.pages[page]
+
+	box := -1
+	area := math.Inf(1)
+	for i := range nodes {
+		n := &nodes[i]
+		if !n.isHBox() || n.distance(x, y) > 0 {
+			continue
+		}
+		if r := n.rect(); r.W*r.H < area {
+			box, area = i, r.W*r.H
+		}
+	}
+
+	best := -1
+	bestDist := math.Inf(1)
+	for i := range nodes {
+		n := &nodes[i]
+		if idx.inputs[n.input] == "" || n.kind == '[' || n.kind == 'v' {
+			continue
+		}
+		var d float64
+		if box >= 0 {
+			if n.parent != box {
+				continue
+			}
+			d = n.distance(x, n.y) // 同一行内只比较横向距离
+		} else {
+			d = n.distance(x, y)
+		}
+		if d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best < 0 {
+		best = box
+	}
+	if best < 0 || idx.inputs[nodes[best].input] == "" {
+		return SourceLocation{}, false
+	}
+	return SourceLocation{File: idx.inputs[nodes[best].input], Line: nodes[best].line}, true
+}
+
+// Forward 正向搜索：返回源文件某行在 PDF 中对应的区域，只返回出现该行的第一页
+// 该行没有排版结果（例如空行或注释）时使用最近的有结果的行
+func (idx *synctexIndex) Forward(loc SourceLocation) ([]SyncBox, error) {
+	tags := idx.matchInputs(loc.File)
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("SyncTeX 中没有源文件 %s", loc.File)
+	}
+
+	// 找出最接近的行号，距离相同时取后面的行
+	line, best := 0, math.MaxInt
+	for _, nodes := range idx.pages {
+		for i := range nodes {
+			n := &nodes[i]
+			if !tags[n.input] {
+				continue
+			}
+			d := n.line - loc.Line
+			if d < 0 {
+				d = -d
+			}
+			if d < best || (d == best && n.line > line) {
+				line, best = n.line, d
+			}
+		}
+	}
+	if best == math.MaxInt {
+		return nil, fmt.Errorf("%s 在 PDF 中没有对应内容", loc)
+	}
+
+	for page := 1; page <= idx.lastPage(); page++ {
+		var boxes []SyncBox
+		seen := map[int]bool{}
+		nodes := idx.pages[page]
+		for i := range nodes {
+			n := &nodes[i]
+			if !tags[n.input] || n.line != line || n.kind == '[' || n.kind == 'v' {
+				continue
+			}
+			// 点状节点用所在的行表示
+			target := i
+			if !n.isHBox() {
+				if n.parent < 0 || !nodes[n.parent].isHBox() {
+					continue
+				}
+				target = n.parent
+			}
+			if !seen[target] {
+				seen[target] = true
+				boxes = append(boxes, SyncBox{Page: page, Rect: nodes[target].rect()})
+			}
+		}
+		if len(boxes) > 0 {
+			return boxes, nil
+		}
+	}
+	return nil, fmt.Errorf("%s 在 PDF 中没有对应内容", loc)
+}
+
+// matchInputs 按路径匹配源文件编号，完整路径都不匹配时按文件名匹配
+func (idx *synctexIndex) matchInputs(file string) map[int]bool {
+	tags := map[int]bool{}
+	if abs, err := filepath.Abs(file); err == nil {
+		for tag, path := range idx.inputs {
+			if path == abs {
+				tags[tag] = true
+			}
+		}
+	}
+	if len(tags) == 0 {
+		for tag, path := range idx.inputs {
+			if filepath.Base(path) == filepath.Base(file) {
+				tags[tag] = true
+			}
+		}
+	}
+	return tags
+}
+
+// lastPage 返回有记录的最大页码
+func (idx *synctexIndex) lastPage() int {
+	last := 0
+	for page := range idx.pages {
+		if page > last {
+			last = page
+		}
+	}
+	return last
+}
+
+// GetSyncTeX 读取文档旁边的 SyncTeX 文件（首次使用时解析）
+func (e *PDFEngine) GetSyncTeX() (*synctexIndex, error) {
+	e.mu.Lock()
+	idx := e.synctex
+	e.mu.Unlock()
+
+	if idx != nil {
+		return idx, nil
+	}
+	if e.filePath == "" {
+		return nil, ErrNoSyncTeX
+	}
+
+	// 解析较慢，不占用 MuPDF 的锁；同时解析时保留先完成的结果
+	idx, err := loadSyncTeX(e.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.synctex == nil {
+		e.synctex = idx
+	}
+	return e.synctex, nil
+}
+
+// editorCommand 按编辑器命令模板生成命令：%f 替换为源文件路径，%l 替换为行号
+// 模板按空白分隔参数，可用双引号包含空格；不经过 shell 执行
+func editorCommand(template string, loc SourceLocation) (*exec.Cmd, error) {
+	args := splitCommandLine(template)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("未设置编辑器命令")
+	}
+
+	replacer := strings.NewReplacer("%f", loc.File, "%l", strconv.Itoa(loc.Line))
+	for i, arg := range args {
+		args[i] = replacer.Replace(arg)
+	}
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+// splitCommandLine 按空白拆分命令行，双引号内的空白不拆分
+func splitCommandLine(s string) []string {
+	var args []string
+	var current strings.Builder
+	inQuotes, hasArg := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(r)
+			hasArg = true
+		}
+	}
+	if hasArg {
+		args = append(args, current.String())
+	}
+	return args
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSyncTeX 两页文档的 SyncTeX 数据，单位取 1bp，坐标直接以点表示
+const testSyncTeX = `SyncTeX Version:1
+Input:1:./paper.tex
+Input:2:/usr/share/texmf/tex/latex/base/article.cls
+Output:pdf
+Magnification:1000
+Unit:65781.76
+X Offset:0
+Y Offset:0
+Content:
+!200
+{1
+[1,3:72,72:450,700,0
+(1,5:72,100:450,10,2
+g1,5:100,100
+k1,6:300,100:5
+)
+(1,8:72,120:450,10,2
+x1,8:72,120
+)
+]
+}1
+{2
+[1,3:72,72:450,700,0
+(1,12,4:72,100:450,10,2
+$1,12:150,100
+)
+]
+}2
+Postamble:
+Count:10
+Post scriptum:
+`
+
+func TestSyncTeXInverse(t *testing.T) {
+	idx, err := parseSyncTeX(strings.NewReader(testSyncTeX), "/home/user/paper")
+	if err != nil {
+		t.Fatalf("parseSyncTeX() = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y float64
+		want int
+	}{
+		{name: "nearest node in line", x: 310, y: 98, want: 6},
+		{name: "other line", x: 80, y: 118, want: 8},
+		{name: "below all lines", x: 80, y: 300, want: 8},
+	}
+	for _, tt := range tests {
+		loc, ok := idx.Inverse(1, tt.x, tt.y)
+		if !ok || loc.Line != tt.want || loc.File != "/home/user/paper/paper.tex" {
+			t.Errorf("%s: Inverse() = %v, %v; want /home/user/paper/paper.tex:%d", tt.name, loc, ok, tt.want)
+		}
+	}
+	if _, ok := idx.Inverse(3, 100, 100); ok {
+		t.Error("Inverse() on a page without records should fail")
+	}
+}
+
+func TestSyncTeXForward(t *testing.T) {
+	idx, err := parseSyncTeX(strings.NewReader(testSyncTeX), "/home/user/paper")
+	if err != nil {
+		t.Fatalf("parseSyncTeX() = %v", err)
+	}
+
+	boxes, err := idx.Forward(SourceLocation{File: "/home/user/paper/paper.tex", Line: 8})
+	want := []SyncBox{{Page: 1, Rect: PageRect{X: 72, Y: 110, W: 450, H: 12}}}
+	if err != nil || !reflect.DeepEqual(boxes, want) {
+		t.Errorf("Forward(line 8) = %v, %v; want %v", boxes, err, want)
+	}
+
+	// 点状节点用所在的行表示；空行取最近的有内容的行（距离相同时取后面的行）
+	for _, line := range []int{12, 10} {
+		boxes, err = idx.Forward(SourceLocation{File: "paper.tex", Line: line})
+		if err != nil || len(boxes) != 1 || boxes[0].Page != 2 {
+			t.Errorf("Forward(line %d) = %v, %v; want one box on page 2", line, boxes, err)
+		}
+	}
+
+	if _, err := idx.Forward(SourceLocation{File: "other.tex", Line: 1}); err == nil {
+		t.Error("Forward() for an unknown file should fail")
+	}
+}
+
+func TestParseSourceLocation(t *testing.T) {
+	loc, err := ParseSourceLocation(`C:\paper\main.tex:42`)
+	if err != nil || loc.File != `C:\paper\main.tex` || loc.Line != 42 {
+		t.Errorf("ParseSourceLocation() = %v, %v", loc, err)
+	}
+	for _, s := range []string{"main.tex", "main.tex:x", "main.tex:0", ":3"} {
+		if _, err := ParseSourceLocation(s); err == nil {
+			t.Errorf("ParseSourceLocation(%q) should fail", s)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	cmd, err := editorCommand(`code --goto "%f:%l"`, SourceLocation{File: "/tmp/my paper.tex", Line: 7})
+	if err != nil {
+		t.Fatalf("editorCommand() = %v", err)
+	}
+	want := []string{"code", "--goto", "/tmp/my paper.tex:7"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("editorCommand() args = %q, want %q", cmd.Args, want)
+	}
+
+	if _, err := editorCommand("  ", SourceLocation{}); err == nil {
+		t.Error("editorCommand() with an empty template should fail")
+	}
+}
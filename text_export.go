@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextFormat 文本导出格式
+type TextFormat string
+
+const (
+	TextPlain    TextFormat = "txt"
+	TextHTML     TextFormat = "html"
+	TextMarkdown TextFormat = "md"
+)
+
+// parseTextFormat 解析文本格式名称（不区分大小写）
+func parseTextFormat(name string) (TextFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "txt", "text", "plain":
+		return TextPlain, nil
+	case "html", "htm":
+		return TextHTML, nil
+	case "md", "markdown":
+		return TextMarkdown, nil
+	}
+	return "", fmt.Errorf("不支持的文本格式: %s", name)
+}
+
+// Extension 返回导出文件的扩展名
+func (f TextFormat) Extension() string {
+	return "." + string(f)
+}
+
+// textHTMLHeader HTML 导出的文档头，样式与 mutool 的 HTML 输出一致
+const textHTMLHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body{background-color:slategray}
+div{position:relative;background-color:white;margin:1em auto;box-shadow:1px 1px 8px -2px black}
+p{position:absolute;white-space:pre;margin:0}
+</style>
+</head>
+<body>
+`
+
+// ExportText 将指定页面的文本按格式写入 w
+func (e *PDFEngine) ExportText(ctx context.Context, w io.Writer, pages []int, format TextFormat) error {
+	bw := bufio.NewWriter(w)
+
+	var err error
+	switch format {
+	case TextPlain:
+		err = e.writePlainText(ctx, bw, pages)
+	case TextHTML:
+		err = e.writeHTMLText(ctx, bw, pages)
+	case TextMarkdown:
+		err = e.writeMarkdown(ctx, bw, pages)
+	default:
+		err = fmt.Errorf("不支持的文本格式: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writePlainText 写入纯文本，页与页之间用换页符分隔
+func (e *PDFEngine) writePlainText(ctx context.Context, w *bufio.Writer, pages []int) error {
+	for i, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		text, err := e.GetPageText(page)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			w.WriteString("\f")
+		}
+		w.WriteString(text)
+	}
+	return nil
+}
+
+// writeHTMLText 写入 MuPDF 生成的 HTML，每页一个 div
+func (e *PDFEngine) writeHTMLText(ctx context.Context, w *bufio.Writer, pages []int) error {
+	fmt.Fprintf(w, textHTMLHeader, html.EscapeString(e.GetFileName()))
+	for _, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		out, err := e.GetPageHTML(page)
+		if err != nil {
+			return err
+		}
+		w.WriteString(out)
+		w.WriteString("\n")
+	}
+	w.WriteString("</body>\n</html>\n")
+	return nil
+}
+
+// writeMarkdown 写入 Markdown 近似结果：按字号推断标题，相邻的行合并为段落
+func (e *PDFEngine) writeMarkdown(ctx context.Context, w *bufio.Writer, pages []int) error {
+	// 先读取全部行，以整个导出范围内最常见的字号作为正文字号
+	pageLines := make([][]TextLine, 0, len(pages))
+	for _, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		lines, err := e.GetPageLines(page)
+		if err != nil {
+			return err
+		}
+		pageLines = append(pageLines, lines)
+	}
+
+	bodySize := bodyFontSize(pageLines)
+	for _, lines := range pageLines {
+		writeMarkdownPage(w, lines, bodySize)
+	}
+	return nil
+}
+
+// markdownBlock 正在合并的标题或段落
+type markdownBlock struct {
+	level int // 标题级别，0 表示段落
+	parts []string
+	last  TextLine
+}
+
+// writeMarkdownPage 将一页的文本行转换为 Markdown 块
+func writeMarkdownPage(w *bufio.Writer, lines []TextLine, bodySize float64) {
+	var block *markdownBlock
+	flush := func() {
+		if block == nil {
+			return
+		}
+		text := joinLines(block.parts)
+		if block.level > 0 {
+			w.WriteString(strings.Repeat("#", block.level) + " ")
+		}
+		w.WriteString(text)
+		w.WriteString("\n\n")
+		block = nil
+	}
+
+	for _, line := range lines {
+		text := strings.TrimSpace(line.Text)
+		level := headingLevel(line.FontSize, bodySize)
+
+		// 同级且行距正常的相邻行属于同一块，较大的空隙表示新段落
+		if block != nil {
+			gap := line.Y - (block.last.Y + block.last.Height)
+			if block.level != level || gap > 0.6*block.last.Height || gap < -block.last.Height {
+				flush()
+			}
+		}
+		if block == nil {
+			block = &markdownBlock{level: level}
+		}
+		block.parts = append(block.parts, text)
+		block.last = line
+	}
+	flush()
+}
+
+// headingLevel 根据字号与正文字号之比推断标题级别
+func headingLevel(size, bodySize float64) int {
+	ratio := size / bodySize
+	switch {
+	case ratio >= 1.8:
+		return 1
+	case ratio >= 1.4:
+		return 2
+	case ratio >= 1.15:
+		return 3
+	}
+	return 0
+}
+
+// bodyFontSize 按字符数加权统计最常见的字号（取 0.5pt 精度）
+func bodyFontSize(pageLines [][]TextLine) float64 {
+	counts := make(map[float64]int)
+	for _, lines := range pageLines {
+		for _, line := range lines {
+			size := math.Round(line.FontSize*2) / 2
+			counts[size] += utf8.RuneCountInString(line.Text)
+		}
+	}
+
+	bodySize, best := float64(defaultLayoutFontSize), 0
+	for size, n := range counts {
+		if n > best || (n == best && size < bodySize) {
+			bodySize, best = size, n
+		}
+	}
+	return bodySize
+}
+
+// joinLines 合并段落中的行：行尾连字符直接拼接，中日韩文字之间不加空格
+func joinLines(parts []string) string {
+	var sb strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			prev := sb.String()
+			last, _ := utf8.DecodeLastRuneInString(prev)
+			first, _ := utf8.DecodeRuneInString(part)
+			switch {
+			case strings.HasSuffix(prev, "-") && unicode.IsLower(first):
+				s := strings.TrimSuffix(prev, "-")
+				sb.Reset()
+				sb.WriteString(s)
+			case isCJK(last) || isCJK(first):
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// isCJK 判断字符是否为中日韩文字或全角标点
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
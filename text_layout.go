@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// avgCharWidthRatio 平均字符宽度与字号之比
+// 仅在 MuPDF 没有输出字形位置时（例如 Type 3 字体、竖排文字）用于估算字符位置
+const avgCharWidthRatio = 0.5
+
+// maxSkippedChars 对齐字形时最多连续跳过的字符数（没有字形的空格、连字的后续字符）
+const maxSkippedChars = 3
+
+var (
+	htmlLineRe     = regexp.MustCompile(`(?s)<p style="top:(-?[\d.]+)pt;left:(-?[\d.]+)pt;line-height:(-?[\d.]+)pt">(.*?)</p>`)
+	htmlFontSizeRe = regexp.MustCompile(`font-size:([\d.]+)pt`)
+	htmlTagRe      = regexp.MustCompile(`<[^>]*>`)
+
+	svgGlyphUseRe  = regexp.MustCompile(`<use data-text="([^"]*)" xlink:href="#([^"]+)" transform="matrix\(([^)]*)\)"`)
+	svgGlyphPathRe = regexp.MustCompile(`<path id="(font_[^"]+)" d="([^"]*)"`)
+	svgPathTokenRe = regexp.MustCompile(`[A-Za-z]|[-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+)
+
+// PageRect 页面坐标系中的矩形（单位：点，1/72 英寸）
+type PageRect struct {
+	X, Y, W, H float64
+}
+
+// TextLine 页面上的一行文本及其位置
+type TextLine struct {
+	Text     string
+	X, Y     float64 // 左上角（点）
+	Height   float64
+	FontSize float64 // 行内最大字号
+	Bold     bool
+
+	chars []charSpan // 各字符（rune）的水平范围，由 locateChars 填入，可能只覆盖行首的一部分
+}
+
+// charSpan 单个字符的水平范围（点）
+type charSpan struct {
+	x0, x1 float64
+}
+
+// textGlyph MuPDF 输出的一个字形（单位：点）
+type textGlyph struct {
+	text   string
+	x0, x1 float64 // x0 为基线原点，x1 为字形轮廓的右边界，轮廓为空（空格）时等于 x0
+	y      float64 // 基线
+	size   float64
+}
+
+// CharRect 返回行内第 start 到 end 个字符（rune 下标）所占的矩形
+// 字符已由 locateChars 定位时使用字形的实际位置，否则按字号估算
+func (l TextLine) CharRect(start, end int) PageRect {
+	if start < end && end <= len(l.chars) {
+		return PageRect{
+			X: l.chars[start].x0,
+			Y: l.Y,
+			W: l.chars[end-1].x1 - l.chars[start].x0,
+			H: l.Height,
+		}
+	}
+
+	charWidth := l.FontSize * avgCharWidthRatio
+	return PageRect{
+		X: l.X + float64(start)*charWidth,
+		Y: l.Y,
+		W: float64(end-start) * charWidth,
+		H: l.Height,
+	}
+}
+
+// GetPageLines 提取页面的文本行及位置
+func (e *PDFEngine) GetPageLines(pageNum int) ([]TextLine, error) {
+	out, err := e.GetPageHTML(pageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTextLines(out), nil
+}
+
+// GetPageGlyphs 返回页面上各字形的文本和位置
+// go-fitz 不提供单个字符的坐标，MuPDF 的 SVG 输出为每个字形写出 data-text 和变换矩阵，从中读取
+func (e *PDFEngine) GetPageGlyphs(pageNum int) ([]textGlyph, error) {
+	svg, err := e.GetPageSVG(pageNum)
+	if err != nil {
+		return nil, err
+	}
+	return parseGlyphs(svg), nil
+}
+
+// GetPageHTML 返回 MuPDF 生成的页面结构化文本 HTML（不含文档头）
+func (e *PDFEngine) GetPageHTML(pageNum int) (string, error) {
+	e.mu.Lock()
+	if err := e.checkPageLocked(pageNum); err != nil {
+		e.mu.Unlock()
+		return "", err
+	}
+	out, err := e.document.HTML(pageNum-1, false)
+	e.mu.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("提取文本失败: %w", err)
+	}
+	return out, nil
+}
+
+// parseTextLines 解析 MuPDF 结构化文本的 HTML 输出
+func parseTextLines(out string) []TextLine {
+	var lines []TextLine
+	for _, m := range htmlLineRe.FindAllStringSubmatch(out, -1) {
+		top, _ := strconv.ParseFloat(m[1], 64)
+		left, _ := strconv.ParseFloat(m[2], 64)
+		height, _ := strconv.ParseFloat(m[3], 64)
+		body := m[4]
+
+		fontSize := height
+		for _, fm := range htmlFontSizeRe.FindAllStringSubmatch(body, -1) {
+			if size, err := strconv.ParseFloat(fm[1], 64); err == nil && size > fontSize {
+				fontSize = size
+			}
+		}
+
+		text := html.UnescapeString(htmlTagRe.ReplaceAllString(body, ""))
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		lines = append(lines, TextLine{
+			Text:     text,
+			X:        left,
+			Y:        top,
+			Height:   height,
+			FontSize: fontSize,
+			Bold:     strings.Contains(body, "<b>"),
+		})
+	}
+	return lines
+}
+
+// parseGlyphs 解析 MuPDF SVG 输出中的字形，只保留水平书写的文字
+func parseGlyphs(svg string) []textGlyph {
+	// 字形轮廓按字号 1 定义，记录各轮廓的右边界
+	extents := make(map[string]float64)
+	for _, m := range svgGlyphPathRe.FindAllStringSubmatch(svg, -1) {
+		extents[m[1]] = pathMaxX(m[2])
+	}
+
+	var glyphs []textGlyph
+	for _, m := range svgGlyphUseRe.FindAllStringSubmatch(svg, -1) {
+		parts := strings.Split(m[3], ",")
+		if len(parts) != 6 {
+			continue
+		}
+		var matrix [6]float64
+		valid := true
+		for i, part := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				valid = false
+				break
+			}
+			matrix[i] = v
+		}
+		if !valid || matrix[0] <= 0 || matrix[1] != 0 || matrix[2] != 0 {
+			continue
+		}
+
+		x := matrix[4]
+		glyphs = append(glyphs, textGlyph{
+			text: html.UnescapeString(m[1]),
+			x0:   x,
+			x1:   x + math.Max(extents[m[2]], 0)*matrix[0],
+			y:    matrix[5],
+			size: math.Abs(matrix[3]),
+		})
+	}
+	return glyphs
+}
+
+// pathMaxX 返回 SVG 路径数据中 x 坐标的最大值（MuPDF 只输出绝对坐标命令）
+func pathMaxX(d string) float64 {
+	maxX := 0.0
+	cmd := byte('M')
+	n := 0 // 当前命令已读取的参数个数
+	for _, tok := range svgPathTokenRe.FindAllString(d, -1) {
+		if c := tok[0]; (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			cmd, n = c, 0
+			continue
+		}
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			continue
+		}
+
+		isX := false
+		switch cmd {
+		case 'M', 'L', 'C', 'S', 'Q', 'T':
+			isX = n%2 == 0
+		case 'H':
+			isX = true
+		case 'A':
+			isX = n%7 == 5
+		}
+		if isX && v > maxX {
+			maxX = v
+		}
+		n++
+	}
+	return maxX
+}
+
+// locateChars 将基线与本行一致的字形按顺序与行内字符对应，得到各字符的水平范围
+// 结构化文本中的空格可能没有字形（由字距推断），连字（如 fi）只有第一个字符有字形；
+// 无法对应时停止，之后的字符按字号估算
+func (l *TextLine) locateChars(glyphs []textGlyph) {
+	runes := []rune(l.Text)
+	baseline := l.Y + 0.8*l.Height // MuPDF 的 HTML 输出中 top = 基线 - 0.8 × 首字符字号
+	tolerance := math.Max(0.5, 0.2*l.Height)
+
+	var row []textGlyph
+	for _, g := range glyphs {
+		if math.Abs(g.y-baseline) <= tolerance && g.x0 >= l.X-tolerance {
+			row = append(row, g)
+		}
+	}
+	sort.SliceStable(row, func(i, j int) bool { return row[i].x0 < row[j].x0 })
+
+	// right 返回字形的右边界：空格等没有轮廓的字形延伸到下一个字形
+	right := func(i int) float64 {
+		g := row[i]
+		if g.x1 > g.x0 {
+			return g.x1
+		}
+		if i+1 < len(row) && row[i+1].x0 > g.x0 {
+			return math.Min(row[i+1].x0, g.x0+g.size)
+		}
+		return g.x0 + g.size*avgCharWidthRatio
+	}
+
+	chars := make([]charSpan, 0, len(runes))
+	last := -1     // 上一个对应上的字形
+	lastStart := 0 // 上一个字形对应的第一个字符
+	for i, g := range row {
+		if len(chars) == len(runes) {
+			break
+		}
+		text := []rune(g.text)
+		if len(text) == 0 {
+			continue
+		}
+		if last < 0 && g.x0-l.X > l.Height {
+			break // 行首没有字形，不是本行的文字
+		}
+		if last >= 0 && g.text == row[last].text && g.x0-row[last].x0 < 0.1*g.size {
+			continue // 重复绘制的字形（模拟粗体）
+		}
+
+		skip := -1
+		for k := 0; k <= maxSkippedChars && len(chars)+k+len(text) <= len(runes); k++ {
+			if string(runes[len(chars)+k:len(chars)+k+len(text)]) == g.text {
+				skip = k
+				break
+			}
+		}
+		if skip < 0 {
+			if strings.TrimSpace(g.text) == "" {
+				continue // 结构化文本中合并掉的空格
+			}
+			break
+		}
+
+		if skip > 0 {
+			skipped := runes[len(chars) : len(chars)+skip]
+			if strings.TrimSpace(string(skipped)) == "" || last < 0 {
+				// 没有字形的空格：占据前后字形之间的空隙
+				x0 := l.X
+				if len(chars) > 0 {
+					x0 = chars[len(chars)-1].x1
+				}
+				chars = appendSpans(chars, skip, x0, math.Max(g.x0, x0))
+			} else {
+				// 连字的后续字符：与前一个字形的字符平分它的宽度
+				chars = appendSpans(chars[:lastStart], len(chars)-lastStart+skip, row[last].x0, right(last))
+			}
+		}
+
+		lastStart = len(chars)
+		chars = appendSpans(chars, len(text), g.x0, right(i))
+		last = i
+	}
+
+	l.chars = chars
+}
+
+// appendSpans 将 [x0, x1] 平分给 n 个字符
+func appendSpans(chars []charSpan, n int, x0, x1 float64) []charSpan {
+	width := (x1 - x0) / float64(n)
+	for i := 0; i < n; i++ {
+		chars = append(chars, charSpan{x0: x0 + float64(i)*width, x1: x0 + float64(i+1)*width})
+	}
+	return chars
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// testGlyphSVG MuPDF SVG 输出的片段：一行 "Ta fix"（空格没有字形，fi 为连字）和下一行的一个字形
+const testGlyphSVG = `<path id="font_1_1" d="M0 0H.6V.7H0Z"/>
+<path id="font_1_2" d="M.05 0L.45 .5 .2 .1Z"/>
+<use data-text="T" xlink:href="#font_1_1" transform="matrix(10,0,0,-10,100,208)" fill="#000000"/>
+<use data-text="a" xlink:href="#font_1_2" transform="matrix(10,0,0,-10,106,208)" fill="#000000"/>
+<use data-text="f" xlink:href="#font_1_1" transform="matrix(10,0,0,-10,114,208)" fill="#000000"/>
+<use data-text="x" xlink:href="#font_1_2" transform="matrix(10,0,0,-10,120,208)" fill="#000000"/>
+<use data-text="Z" xlink:href="#font_1_1" transform="matrix(10,0,0,-10,100,230)" fill="#000000"/>`
+
+func TestLocateChars(t *testing.T) {
+	line := TextLine{Text: "Ta fix", X: 100, Y: 200, Height: 10, FontSize: 10}
+	line.locateChars(parseGlyphs(testGlyphSVG))
+
+	tests := []struct {
+		start, end int
+		want       PageRect
+	}{
+		{0, 1, PageRect{X: 100, Y: 200, W: 6, H: 10}},
+		{1, 2, PageRect{X: 106, Y: 200, W: 4.5, H: 10}},
+		{2, 3, PageRect{X: 110.5, Y: 200, W: 3.5, H: 10}}, // 没有字形的空格
+		{3, 6, PageRect{X: 114, Y: 200, W: 10.5, H: 10}},  // 连字与之后的字符
+		{4, 5, PageRect{X: 117, Y: 200, W: 3, H: 10}},
+	}
+	for _, tt := range tests {
+		got := line.CharRect(tt.start, tt.end)
+		if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.W-tt.want.W) > 1e-9 || got.Y != tt.want.Y || got.H != tt.want.H {
+			t.Errorf("CharRect(%d, %d) = %+v, want %+v", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	// 没有字形时按字号估算
+	other := TextLine{Text: "abc", X: 10, Y: 50, Height: 10, FontSize: 10}
+	other.locateChars(parseGlyphs(testGlyphSVG))
+	if got, want := other.CharRect(1, 3), (PageRect{X: 15, Y: 50, W: 10, H: 10}); got != want {
+		t.Errorf("CharRect without glyphs = %+v, want %+v", got, want)
+	}
+}
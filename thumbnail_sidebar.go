@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"strconv"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 缩略图宽度（像素），高度按 A4 比例预留
+const (
+	defaultThumbnailSize = 120
+	minThumbnailSize     = 60
+	maxThumbnailSize     = 240
+	thumbnailSizeStep    = 20
+)
+
+// thumbnailAspect 缩略图框的高宽比（A4 纵向）
+const thumbnailAspect = 1.414
+
+// thumbnailCacheLimit 最多缓存的缩略图数量，超过后丢弃不在视口中的图像
+const thumbnailCacheLimit = 300
+
+// thumbnail 渲染好的缩略图及渲染时使用的选项，旋转改变后需要重新渲染
+type thumbnail struct {
+	image image.Image
+	opts  RenderOptions
+}
+
+// thumbnailItem 列表中的一行：页面缩略图和页码
+type thumbnailItem struct {
+	widget.BaseWidget
+	image *canvas.Image
+	frame *canvas.Rectangle
+	label *widget.Label
+	page  int // 当前绑定的页码，0 表示未绑定
+}
+
+func newThumbnailItem(size float32) *thumbnailItem {
+	item := &thumbnailItem{
+		image: canvas.NewImageFromImage(nil),
+		frame: canvas.NewRectangle(color.White),
+		label: widget.NewLabel(""),
+	}
+	item.image.FillMode = canvas.ImageFillContain
+	item.label.Alignment = fyne.TextAlignCenter
+	item.setSize(size)
+	item.ExtendBaseWidget(item)
+	return item
+}
+
+// setSize 按缩略图宽度设置图像框大小
+func (item *thumbnailItem) setSize(size float32) {
+	box := fyne.NewSize(size, size*thumbnailAspect)
+	item.frame.SetMinSize(box)
+	item.image.SetMinSize(box)
+}
+
+func (item *thumbnailItem) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewVBox(
+		container.NewCenter(container.NewStack(item.frame, item.image)),
+		item.label,
+	))
+}
+
+// thumbnailSidebar 标签页的缩略图侧边栏
+// 列表只为可见行创建控件，缩略图在后台按需渲染，离开视口的请求直接丢弃
+type thumbnailSidebar struct {
+	list      *widget.List
+	slider    *widget.Slider
+	container *fyne.Container
+
+	syncing     bool // 正在根据当前页同步选中项，忽略选中回调
+	onNavigate  func(page int)
+	pageOptions func(page int) RenderOptions // 页面当前的渲染选项（旋转）
+	canvasScale func() float32               // 画布缩放比例，缩略图按物理像素渲染
+
+	mu         sync.Mutex
+	engine     DocumentEngine
+	pageCount  int
+	size       int
+	scale      float32
+	generation uint64 // 文档或缩略图大小变化时递增，使旧的渲染结果作废
+	cache      map[int]thumbnail
+	shown      map[int]*thumbnailItem // 当前显示在列表中的页
+	queue      []int
+	queued     map[int]RenderOptions
+
+	wake    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// newThumbnailSidebar 创建缩略图侧边栏（默认隐藏）并启动渲染协程
+// pageOptions 和 canvasScale 在界面协程中调用
+func newThumbnailSidebar(onNavigate func(page int), pageOptions func(page int) RenderOptions, canvasScale func() float32) *thumbnailSidebar {
+	ctx, cancel := context.WithCancel(context.Background())
+	sb := &thumbnailSidebar{
+		onNavigate:  onNavigate,
+		pageOptions: pageOptions,
+		canvasScale: canvasScale,
+		size:        defaultThumbnailSize,
+		scale:       1,
+		cache:       map[int]thumbnail{},
+		shown:       map[int]*thumbnailItem{},
+		queued:      map[int]RenderOptions{},
+		wake:        make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+		stopped:     make(chan struct{}),
+	}
+
+	sb.list = widget.NewList(
+		func() int {
+			sb.mu.Lock()
+			defer sb.mu.Unlock()
+			return sb.pageCount
+		},
+		func() fyne.CanvasObject {
+			sb.mu.Lock()
+			size := sb.size
+			sb.mu.Unlock()
+			return newThumbnailItem(float32(size))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			sb.bind(obj.(*thumbnailItem), id+1)
+		},
+	)
+	sb.list.OnSelected = func(id widget.ListItemID) {
+		if sb.syncing {
+			return
+		}
+		if sb.onNavigate != nil {
+			sb.onNavigate(id + 1)
+		}
+	}
+
+	sb.slider = widget.NewSlider(minThumbnailSize, maxThumbnailSize)
+	sb.slider.Step = thumbnailSizeStep
+	sb.slider.Value = defaultThumbnailSize
+	sb.slider.OnChangeEnded = func(value float64) {
+		sb.SetSize(int(value))
+	}
+
+	sb.container = container.NewBorder(nil, sb.slider, nil, nil, sb.list)
+	sb.container.Hide()
+
+	go sb.run()
+	return sb
+}
+
+// SetDocument 切换到新文档（或重新排版后的同一文档），清空已渲染的缩略图
+func (sb *thumbnailSidebar) SetDocument(engine DocumentEngine) {
+	sb.mu.Lock()
+	sb.engine = engine
+	sb.pageCount = 0
+	if engine != nil {
+		sb.pageCount = engine.GetPageCount()
+	}
+	sb.resetLocked()
+	sb.mu.Unlock()
+
+	sb.list.UnselectAll()
+	sb.list.Refresh()
+}
+
+// SetSize 设置缩略图宽度并重新渲染可见页
+func (sb *thumbnailSidebar) SetSize(size int) {
+	if size < minThumbnailSize {
+		size = minThumbnailSize
+	} else if size > maxThumbnailSize {
+		size = maxThumbnailSize
+	}
+
+	sb.mu.Lock()
+	if size == sb.size {
+		sb.mu.Unlock()
+		return
+	}
+	sb.size = size
+	sb.resetLocked()
+	sb.mu.Unlock()
+
+	// 列表在刷新时按新的模板重新计算行高
+	sb.list.Refresh()
+}
+
+// Refresh 重新绑定可见行，旋转改变的页按新的方向重新渲染
+func (sb *thumbnailSidebar) Refresh() {
+	sb.list.Refresh()
+}
+
+// resetLocked 丢弃缓存和排队的请求（调用方持有 mu）
+func (sb *thumbnailSidebar) resetLocked() {
+	sb.generation++
+	sb.cache = map[int]thumbnail{}
+	sb.shown = map[int]*thumbnailItem{}
+	sb.queue = nil
+	sb.queued = map[int]RenderOptions{}
+}
+
+// bind 将列表行绑定到页面，缩略图未渲染或旋转已改变时加入队列
+func (sb *thumbnailSidebar) bind(item *thumbnailItem, page int) {
+	var opts RenderOptions
+	if sb.pageOptions != nil {
+		opts = sb.pageOptions(page)
+	}
+	scale := float32(1)
+	if sb.canvasScale != nil {
+		scale = sb.canvasScale()
+	}
+
+	sb.mu.Lock()
+	if item.page != 0 && sb.shown[item.page] == item {
+		delete(sb.shown, item.page)
+	}
+	item.page = page
+	sb.shown[page] = item
+	sb.scale = scale
+	size := sb.size
+	var img image.Image
+	if cached, ok := sb.cache[page]; ok && cached.opts == opts {
+		img = cached.image
+	} else {
+		if _, ok := sb.queued[page]; !ok {
+			sb.queue = append(sb.queue, page)
+		}
+		sb.queued[page] = opts
+	}
+	sb.mu.Unlock()
+
+	item.setSize(float32(size))
+	item.label.SetText(strconv.Itoa(page))
+	item.image.Image = img
+	item.image.Refresh()
+
+	if img == nil {
+		select {
+		case sb.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// SyncToPage 高亮当前页并滚动到可见位置
+func (sb *thumbnailSidebar) SyncToPage(page int) {
+	if page < 1 {
+		sb.list.UnselectAll()
+		return
+	}
+
+	sb.syncing = true
+	sb.list.Select(page - 1)
+	sb.syncing = false
+}
+
+// SetVisible 显示或隐藏侧边栏
+func (sb *thumbnailSidebar) SetVisible(visible bool) {
+	if visible {
+		sb.container.Show()
+	} else {
+		sb.container.Hide()
+	}
+}
+
+// Visible 侧边栏是否可见
+func (sb *thumbnailSidebar) Visible() bool {
+	return sb.container.Visible()
+}
+
+// Stop 停止渲染协程，返回前等待正在进行的渲染结束
+func (sb *thumbnailSidebar) Stop() {
+	sb.cancel()
+	<-sb.stopped
+}
+
+// run 渲染协程：依次处理仍在列表中显示的页面
+func (sb *thumbnailSidebar) run() {
+	defer close(sb.stopped)
+
+	for {
+		select {
+		case <-sb.ctx.Done():
+			return
+		case <-sb.wake:
+		}
+
+		for sb.ctx.Err() == nil {
+			page, opts, ok := sb.nextPage()
+			if !ok {
+				break
+			}
+			sb.render(page, opts)
+		}
+	}
+}
+
+// nextPage 取出下一个仍然可见的页面及其渲染选项，已滚出视口的请求直接丢弃
+func (sb *thumbnailSidebar) nextPage() (int, RenderOptions, bool) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	for len(sb.queue) > 0 {
+		page := sb.queue[0]
+		opts := sb.queued[page]
+		sb.queue = sb.queue[1:]
+		delete(sb.queued, page)
+		if cached, ok := sb.cache[page]; sb.shown[page] != nil && (!ok || cached.opts != opts) {
+			return page, opts, true
+		}
+	}
+	return 0, RenderOptions{}, false
+}
+
+// render 渲染一页缩略图，期间文档或大小变化时丢弃结果
+// 缩略图不经过页面缓存，图像在界面协程中更新
+func (sb *thumbnailSidebar) render(page int, opts RenderOptions) {
+	sb.mu.Lock()
+	engine, size, scale, generation := sb.engine, sb.size, sb.scale, sb.generation
+	sb.mu.Unlock()
+	if engine == nil {
+		return
+	}
+
+	dpi := thumbnailDPI(engine, page, size, opts.Rotation, scale)
+	img, err := engine.RenderPageUncached(page, dpi, opts)
+	if err != nil {
+		return // 保留空白缩略图
+	}
+
+	sb.mu.Lock()
+	if generation != sb.generation {
+		sb.mu.Unlock()
+		return
+	}
+	sb.cache[page] = thumbnail{image: img, opts: opts}
+	sb.evictLocked()
+	sb.mu.Unlock()
+
+	fyne.Do(func() {
+		sb.mu.Lock()
+		item := sb.shown[page]
+		current := generation == sb.generation && sb.cache[page].image == img
+		sb.mu.Unlock()
+
+		if current && item != nil && item.page == page {
+			item.image.Image = img
+			item.image.Refresh()
+		}
+	})
+}
+
+// evictLocked 缓存超过上限时丢弃不在视口中的缩略图（调用方持有 mu）
+func (sb *thumbnailSidebar) evictLocked() {
+	for page := range sb.cache {
+		if len(sb.cache) <= thumbnailCacheLimit {
+			return
+		}
+		if sb.shown[page] == nil {
+			delete(sb.cache, page)
+		}
+	}
+}
+
+// thumbnailDPI 计算使旋转后的页面恰好放入缩略图框的渲染 DPI
+// size 为缩略图框宽度（逻辑像素），scale 为画布缩放比例
+func thumbnailDPI(engine DocumentEngine, page, size, rotation int, scale float32) int {
+	ps, err := engine.GetPageSize(page)
+	if err != nil || ps.Width <= 0 || ps.Height <= 0 {
+		ps = PageSize{Width: 595, Height: 842}
+	}
+	if r := normalizeRotation(rotation); r == 90 || r == 270 {
+		ps.Width, ps.Height = ps.Height, ps.Width
+	}
+
+	width := float64(size) * float64(scale)
+	dpi := width * 72 / ps.Width
+	if h := width * thumbnailAspect * 72 / ps.Height; h < dpi {
+		dpi = h
+	}
+	if dpi < 1 {
+		return 1
+	}
+	return int(dpi)
+}
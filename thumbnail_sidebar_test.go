@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestThumbnailDPI(t *testing.T) {
+	engine := newFakeEngine(2)
+	tests := []struct {
+		name     string
+		size     int
+		rotation int
+		scale    float32
+		want     int
+	}{
+		// A4 为 595 × 842 点，缩略图框为 size × 1.414·size
+		{name: "portrait", size: 120, scale: 1, want: 14},
+		{name: "hidpi canvas", size: 120, scale: 2, want: 29},
+		{name: "rotated to landscape", size: 120, rotation: 90, scale: 2, want: 20},
+		{name: "rotated upside down", size: 120, rotation: 180, scale: 1, want: 14},
+		{name: "never below 1", size: 1, scale: 0.5, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thumbnailDPI(engine, 1, tt.size, tt.rotation, tt.scale); got != tt.want {
+				t.Errorf("thumbnailDPI(size %d, rotation %d, scale %v) = %d, want %d", tt.size, tt.rotation, tt.scale, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,845 +1,1887 @@
-package main
-
-import (
-	"fmt"
-	"io"
-	"net/url"
-	"os"
-	"strconv"
-	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/driver/desktop"
-	"fyne.io/fyne/v2/storage"
-	"fyne.io/fyne/v2/theme"
-	"fyne.io/fyne/v2/widget"
-)
-
-// ViewerUI PDF 阅读器界面
-type ViewerUI struct {
-	window       fyne.Window
-	tabContainer *container.AppTabs
-	tabs         []*PDFTab
-	statusLabel  *widget.Label
-	pageEntry    *widget.Entry
-	zoomLabel    *widget.Button // 显示缩放比例的按钮
-	currentLang  Language       // 当前语言
-	tr           *Translations  // 翻译文本
-}
-
-// PDFTab 表示单个 PDF 标签页
-type PDFTab struct {
-	controller    *Controller
-	imageCanvas   *canvas.Image
-	scrollView    *container.Scroll
-	loadingLabel  *widget.Label
-	canvasWrapper *scrollableCanvas
-	tabItem       *container.TabItem
-}
-
-// NewViewerUI 创建界面实例
-func NewViewerUI(app fyne.App, _ *Controller) *ViewerUI {
-	ui := &ViewerUI{
-		tabs:        []*PDFTab{},
-		currentLang: LangEnglish, // 默认英文
-		tr:          GetTranslations(LangEnglish),
-	}
-
-	window := app.NewWindow(ui.tr.WindowTitle)
-	window.Resize(fyne.NewSize(900, 700))
-
-	// 设置窗口图标
-	window.SetIcon(getAppIcon())
-
-	ui.window = window
-
-	ui.buildUI()
-	ui.setupKeyBindings()
-
-	// 创建初始标签页（空页面）
-	ui.addNewTab("")
-
-	return ui
-}
-
-// NewPDFTab 创建新的 PDF 标签页
-func NewPDFTab(ui *ViewerUI, filePath string) *PDFTab {
-	tab := &PDFTab{
-		controller: NewController(),
-	}
-
-	// 创建标签页内容
-	content := tab.createContent(ui)
-
-	// 创建标签页项
-	tabName := "新建标签"
-	if filePath != "" {
-		tabName = getFileName(filePath)
-	}
-
-	tab.tabItem = container.NewTabItem(tabName, content)
-
-	// 如果指定了文件路径，加载文件
-	if filePath != "" {
-		go func() {
-			tab.loadPDF(filePath, ui)
-		}()
-	}
-
-	return tab
-}
-
-// createContent 创建标签页内容
-func (tab *PDFTab) createContent(ui *ViewerUI) fyne.CanvasObject {
-	// 中央显示区
-	tab.imageCanvas = canvas.NewImageFromImage(nil)
-	tab.imageCanvas.FillMode = canvas.ImageFillOriginal // 使用原始大小，支持缩放
-
-	tab.loadingLabel = widget.NewLabel(ui.tr.MsgDoubleClickOpen)
-	tab.loadingLabel.Alignment = fyne.TextAlignCenter
-
-	centerContent := container.NewStack(
-		tab.imageCanvas,
-		container.NewCenter(tab.loadingLabel),
-	)
-
-	// 创建支持滚轮和双击的 canvas wrapper
-	tab.canvasWrapper = newScrollableCanvas(
-		centerContent,
-		func(ev *fyne.ScrollEvent) { tab.onScrollWheel(ev, ui) },
-		func() { tab.onDoubleTap(ui) },
-	)
-
-	tab.scrollView = container.NewScroll(tab.canvasWrapper)
-
-	return tab.scrollView
-}
-
-// getFileName 从完整路径提取文件名
-func getFileName(path string) string {
-	parts := strings.Split(path, "/")
-	if len(parts) > 0 {
-		name := parts[len(parts)-1]
-		if len(name) > 20 {
-			return name[:17] + "..."
-		}
-		return name
-	}
-	return "PDF 文档"
-}
-
-// buildUI 构建界面
-func (ui *ViewerUI) buildUI() {
-	// 菜单栏
-	ui.window.SetMainMenu(ui.createMenuBar())
-
-	// 顶部工具栏
-	toolbar := ui.createToolbar()
-
-	// 创建标签页容器
-	ui.tabContainer = container.NewAppTabs()
-	ui.tabContainer.SetTabLocation(container.TabLocationTop)
-
-	// 监听标签页切换事件
-	ui.tabContainer.OnSelected = func(tab *container.TabItem) {
-		ui.updateStatusBar()
-		ui.updateZoomLabel()
-	}
-
-	// 底部状态栏
-	statusBar := ui.createStatusBar()
-
-	// 组合布局
-	content := container.NewBorder(
-		toolbar,
-		statusBar,
-		nil, nil,
-		ui.tabContainer,
-	)
-
-	ui.window.SetContent(content)
-}
-
-// addNewTab 添加新标签页
-func (ui *ViewerUI) addNewTab(filePath string) {
-	tab := NewPDFTab(ui, filePath)
-	ui.tabs = append(ui.tabs, tab)
-	ui.tabContainer.Append(tab.tabItem)
-	ui.tabContainer.Select(tab.tabItem)
-	ui.updateStatusBar()
-}
-
-// getCurrentTab 获取当前激活的标签页
-func (ui *ViewerUI) getCurrentTab() *PDFTab {
-	if len(ui.tabs) == 0 {
-		return nil
-	}
-
-	selectedItem := ui.tabContainer.Selected()
-	for _, tab := range ui.tabs {
-		if tab.tabItem == selectedItem {
-			return tab
-		}
-	}
-
-	return nil
-}
-
-// closeCurrentTab 关闭当前标签页
-func (ui *ViewerUI) closeCurrentTab() {
-	currentTab := ui.getCurrentTab()
-	if currentTab == nil {
-		return
-	}
-
-	// 找到并移除标签页
-	for i, tab := range ui.tabs {
-		if tab == currentTab {
-			// 关闭 PDF 引擎
-			if tab.controller.engine != nil {
-				tab.controller.engine.Close()
-			}
-
-			// 从列表中移除
-			ui.tabs = append(ui.tabs[:i], ui.tabs[i+1:]...)
-			ui.tabContainer.Remove(tab.tabItem)
-			break
-		}
-	}
-
-	// 如果没有标签页了，创建一个新的空标签页
-	if len(ui.tabs) == 0 {
-		ui.addNewTab("")
-	}
-
-	ui.updateStatusBar()
-}
-
-// updateStatusBar 更新状态栏
-func (ui *ViewerUI) updateStatusBar() {
-	currentTab := ui.getCurrentTab()
-	if currentTab == nil || !currentTab.controller.HasDocument() {
-		ui.statusLabel.SetText(ui.tr.StatusNoDocument)
-		return
-	}
-
-	ui.statusLabel.SetText(currentTab.controller.GetStatusText(ui.tr))
-}
-
-// updateZoomLabel 更新缩放标签
-func (ui *ViewerUI) updateZoomLabel() {
-	currentTab := ui.getCurrentTab()
-	if currentTab == nil || !currentTab.controller.HasDocument() {
-		ui.zoomLabel.SetText("100%")
-		return
-	}
-
-	zoomPercent := int(currentTab.controller.zoomLevel * 100)
-	ui.zoomLabel.SetText(fmt.Sprintf("%d%%", zoomPercent))
-}
-
-// createMenuBar 创建菜单栏
-func (ui *ViewerUI) createMenuBar() *fyne.MainMenu {
-	// 文件菜单
-	fileMenu := fyne.NewMenu(ui.tr.MenuFile,
-		fyne.NewMenuItem(ui.tr.MenuOpen, ui.onOpenFile),
-		fyne.NewMenuItem(ui.tr.MenuNewTab, func() {
-			ui.addNewTab("")
-		}),
-		fyne.NewMenuItem(ui.tr.MenuSaveAs, ui.onSaveAs),
-		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem(ui.tr.MenuCloseTab, func() {
-			ui.closeCurrentTab()
-		}),
-		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem(ui.tr.MenuExit, func() {
-			ui.window.Close()
-		}),
-	)
-
-	// 查看菜单
-	viewMenu := fyne.NewMenu(ui.tr.MenuView,
-		fyne.NewMenuItem(ui.tr.MenuFirstPage, ui.onFirstPage),
-		fyne.NewMenuItem(ui.tr.MenuPrevPage, ui.onPrevPage),
-		fyne.NewMenuItem(ui.tr.MenuNextPage, ui.onNextPage),
-		fyne.NewMenuItem(ui.tr.MenuLastPage, ui.onLastPage),
-		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem(ui.tr.MenuZoomIn, ui.onZoomIn),
-		fyne.NewMenuItem(ui.tr.MenuZoomOut, ui.onZoomOut),
-		fyne.NewMenuItem(ui.tr.MenuActualSize, ui.onZoomReset),
-	)
-
-	// 语言菜单
-	langMenu := fyne.NewMenu(ui.tr.MenuLanguage,
-		fyne.NewMenuItem(ui.tr.MenuEnglish, func() {
-			ui.switchLanguage(LangEnglish)
-		}),
-		fyne.NewMenuItem(ui.tr.MenuChinese, func() {
-			ui.switchLanguage(LangChinese)
-		}),
-	)
-
-	// 帮助菜单
-	helpMenu := fyne.NewMenu(ui.tr.MenuHelp,
-		fyne.NewMenuItem(ui.tr.MenuShortcuts, ui.onShowShortcuts),
-		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem(ui.tr.MenuAbout, ui.onShowAbout),
-	)
-
-	return fyne.NewMainMenu(fileMenu, viewMenu, langMenu, helpMenu)
-}
-
-// createToolbar 创建工具栏
-func (ui *ViewerUI) createToolbar() fyne.CanvasObject {
-	// 文件按钮
-	openBtn := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), ui.onOpenFile)
-	openBtn.Importance = widget.HighImportance
-
-	// 另存为按钮
-	saveAsBtn := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), ui.onSaveAs)
-
-	// 关闭标签页按钮
-	closeTabBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
-		ui.closeCurrentTab()
-	})
-	closeTabBtn.Importance = widget.DangerImportance
-
-	// 导航按钮
-	firstBtn := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), ui.onFirstPage)
-	prevBtn := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), ui.onPrevPage)
-	nextBtn := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), ui.onNextPage)
-	lastBtn := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), ui.onLastPage)
-
-	// 页码跳转
-	ui.pageEntry = widget.NewEntry()
-	ui.pageEntry.SetPlaceHolder(ui.tr.HintPageEntry)
-	ui.pageEntry.OnSubmitted = ui.onPageJump
-
-	// 缩放按钮
-	zoomOutBtn := widget.NewButtonWithIcon("", theme.ZoomOutIcon(), ui.onZoomOut)
-	ui.zoomLabel = widget.NewButton("100%", ui.onZoomReset)
-	zoomInBtn := widget.NewButtonWithIcon("", theme.ZoomInIcon(), ui.onZoomIn)
-
-	// 组合工具栏
-	toolbar := container.NewHBox(
-		openBtn,
-		saveAsBtn,
-		closeTabBtn,
-		widget.NewSeparator(),
-		firstBtn,
-		prevBtn,
-		ui.pageEntry,
-		nextBtn,
-		lastBtn,
-		widget.NewSeparator(),
-		zoomOutBtn,
-		ui.zoomLabel,
-		zoomInBtn,
-	)
-
-	return toolbar
-}
-
-// createStatusBar 创建状态栏
-func (ui *ViewerUI) createStatusBar() fyne.CanvasObject {
-	ui.statusLabel = widget.NewLabel(ui.tr.StatusNoDocument)
-	return container.NewPadded(ui.statusLabel)
-}
-
-// setupKeyBindings 设置键盘快捷键
-func (ui *ViewerUI) setupKeyBindings() {
-	// 添加 Ctrl+W 快捷键关闭当前标签
-	ui.window.Canvas().AddShortcut(&desktop.CustomShortcut{
-		KeyName:  fyne.KeyW,
-		Modifier: fyne.KeyModifierControl,
-	}, func(shortcut fyne.Shortcut) {
-		ui.closeCurrentTab()
-	})
-
-	ui.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
-		currentTab := ui.getCurrentTab()
-		if currentTab == nil {
-			return
-		}
-
-		switch key.Name {
-		case fyne.KeyLeft, fyne.KeyPageUp:
-			currentTab.onPrevPage(ui)
-		case fyne.KeyRight, fyne.KeyPageDown, fyne.KeySpace:
-			currentTab.onNextPage(ui)
-		case fyne.KeyHome:
-			currentTab.onFirstPage(ui)
-		case fyne.KeyEnd:
-			currentTab.onLastPage(ui)
-		}
-	})
-}
-
-// PDFTab 的滚轮事件处理
-func (tab *PDFTab) onScrollWheel(ev *fyne.ScrollEvent, ui *ViewerUI) {
-	if !tab.controller.HasDocument() {
-		return
-	}
-
-	if ev.Scrolled.DY < 0 {
-		// 向下滚动 → 下一页
-		if tab.controller.NextPage() {
-			tab.renderPage(ui)
-		}
-	} else if ev.Scrolled.DY > 0 {
-		// 向上滚动 → 上一页
-		if tab.controller.PrevPage() {
-			tab.renderPage(ui)
-		}
-	}
-}
-
-// PDFTab 的双击事件处理
-func (tab *PDFTab) onDoubleTap(ui *ViewerUI) {
-	// 仅在未打开文档时响应双击
-	if !tab.controller.HasDocument() {
-		ui.onOpenFile()
-	}
-}
-
-// openFileInCurrentTab 在当前标签页打开文件
-func (ui *ViewerUI) openFileInCurrentTab(filePath string) {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		go func() {
-			currentTab.loadPDF(filePath, ui)
-		}()
-	}
-}
-
-// onSaveAs 另存为
-func (ui *ViewerUI) onSaveAs() {
-	currentTab := ui.getCurrentTab()
-	if currentTab == nil || !currentTab.controller.HasDocument() {
-		dialog.ShowInformation(ui.tr.MenuHelp, ui.tr.MsgNoDocumentToSave, ui.window)
-		return
-	}
-
-	// 获取当前文件路径
-	srcPath := currentTab.controller.engine.GetFilePath()
-
-	// 创建保存对话框
-	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
-		if err != nil || writer == nil {
-			return
-		}
-		defer writer.Close()
-
-		// 复制文件
-		dstPath := writer.URI().Path()
-		err = copyFile(srcPath, dstPath)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf(ui.tr.MsgSaveFailed, err), ui.window)
-			return
-		}
-
-		dialog.ShowInformation(ui.tr.MenuHelp, ui.tr.MsgSaveSuccess, ui.window)
-	}, ui.window)
-
-	// 设置默认文件名
-	fileName := currentTab.controller.engine.GetFileName()
-	saveDialog.SetFileName(fileName)
-	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
-	saveDialog.Show()
-}
-
-// copyFile 复制文件
-func copyFile(src, dst string) error {
-	sourceFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer sourceFile.Close()
-
-	destFile, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer destFile.Close()
-
-	_, err = io.Copy(destFile, sourceFile)
-	if err != nil {
-		return err
-	}
-
-	return destFile.Sync()
-}
-
-// onOpenFile 打开文件对话框
-func (ui *ViewerUI) onOpenFile() {
-	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
-		if err != nil || reader == nil {
-			return
-		}
-		defer reader.Close()
-
-		filePath := reader.URI().Path()
-
-		// 检查当前标签页是否为空
-		currentTab := ui.getCurrentTab()
-		if currentTab != nil && !currentTab.controller.HasDocument() {
-			// 当前标签页为空，直接在当前标签页打开
-			go func() {
-				currentTab.loadPDF(filePath, ui)
-			}()
-		} else {
-			// 当前标签页已有文档，创建新标签页
-			ui.addNewTab(filePath)
-		}
-	}, ui.window)
-
-	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
-	fileDialog.Show()
-}
-
-// loadPDF 加载 PDF 文件（PDFTab 方法）
-func (tab *PDFTab) loadPDF(filePath string, ui *ViewerUI) {
-	tab.showLoading(ui.tr.MsgLoading)
-
-	err := tab.controller.OpenPDF(filePath)
-	if err != nil {
-		tab.showError(fmt.Sprintf(ui.tr.MsgLoadFailed, err))
-		return
-	}
-
-	// 更新标签页标题
-	tab.tabItem.Text = getFileName(filePath)
-	ui.tabContainer.Refresh()
-
-	tab.renderPage(ui)
-	ui.updateStatusBar()
-	ui.updateZoomLabel()
-}
-
-// onFirstPage 跳转到首页
-func (ui *ViewerUI) onFirstPage() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onFirstPage(ui)
-	}
-}
-
-func (tab *PDFTab) onFirstPage(ui *ViewerUI) {
-	if tab.controller.FirstPage() {
-		tab.renderPage(ui)
-		ui.updateStatusBar()
-	}
-}
-
-// onPrevPage 上一页
-func (ui *ViewerUI) onPrevPage() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onPrevPage(ui)
-	}
-}
-
-func (tab *PDFTab) onPrevPage(ui *ViewerUI) {
-	if tab.controller.PrevPage() {
-		tab.renderPage(ui)
-		ui.updateStatusBar()
-	}
-}
-
-// onNextPage 下一页
-func (ui *ViewerUI) onNextPage() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onNextPage(ui)
-	}
-}
-
-func (tab *PDFTab) onNextPage(ui *ViewerUI) {
-	if tab.controller.NextPage() {
-		tab.renderPage(ui)
-		ui.updateStatusBar()
-	}
-}
-
-// onLastPage 跳转到末页
-func (ui *ViewerUI) onLastPage() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onLastPage(ui)
-	}
-}
-
-func (tab *PDFTab) onLastPage(ui *ViewerUI) {
-	if tab.controller.LastPage() {
-		tab.renderPage(ui)
-		ui.updateStatusBar()
-	}
-}
-
-// onPageJump 页码跳转
-func (ui *ViewerUI) onPageJump(text string) {
-	currentTab := ui.getCurrentTab()
-	if currentTab == nil {
-		return
-	}
-
-	pageNum, err := strconv.Atoi(text)
-	if err != nil {
-		dialog.ShowError(fmt.Errorf(ui.tr.MsgInvalidPage), ui.window)
-		return
-	}
-
-	err = currentTab.controller.GoToPage(pageNum)
-	if err != nil {
-		dialog.ShowError(err, ui.window)
-		return
-	}
-
-	currentTab.renderPage(ui)
-	ui.updateStatusBar()
-}
-
-// onZoomIn 放大
-func (ui *ViewerUI) onZoomIn() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onZoomIn(ui)
-	}
-}
-
-func (tab *PDFTab) onZoomIn(ui *ViewerUI) {
-	tab.controller.ZoomIn()
-	tab.renderPage(ui)
-	ui.updateStatusBar()
-	ui.updateZoomLabel()
-}
-
-// onZoomOut 缩小
-func (ui *ViewerUI) onZoomOut() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onZoomOut(ui)
-	}
-}
-
-func (tab *PDFTab) onZoomOut(ui *ViewerUI) {
-	tab.controller.ZoomOut()
-	tab.renderPage(ui)
-	ui.updateStatusBar()
-	ui.updateZoomLabel()
-}
-
-// onZoomReset 重置缩放
-func (ui *ViewerUI) onZoomReset() {
-	currentTab := ui.getCurrentTab()
-	if currentTab != nil {
-		currentTab.onZoomReset(ui)
-	}
-}
-
-func (tab *PDFTab) onZoomReset(ui *ViewerUI) {
-	tab.controller.ResetZoom()
-	tab.renderPage(ui)
-	ui.updateStatusBar()
-	ui.updateZoomLabel()
-}
-
-// renderPage 渲染当前页面（PDFTab 方法）
-func (tab *PDFTab) renderPage(ui *ViewerUI) {
-	if !tab.controller.HasDocument() {
-		return
-	}
-
-	// 不再显示"正在渲染"提示，直接渲染
-	go func() {
-		img, err := tab.controller.RenderCurrentPage()
-		if err != nil {
-			tab.showError(fmt.Sprintf(ui.tr.MsgRenderFailed, err))
-			return
-		}
-
-		// 更新界面
-		tab.imageCanvas.Image = img
-		tab.imageCanvas.Refresh()
-		tab.hideLoading() // 隐藏加载提示
-	}()
-}
-
-// showLoading 显示加载提示（PDFTab 方法）
-func (tab *PDFTab) showLoading(message string) {
-	tab.loadingLabel.SetText(message)
-	tab.loadingLabel.Show()
-}
-
-// hideLoading 隐藏加载提示（PDFTab 方法）
-func (tab *PDFTab) hideLoading() {
-	tab.loadingLabel.Hide()
-}
-
-// showError 显示错误信息（PDFTab 方法）
-func (tab *PDFTab) showError(message string) {
-	tab.loadingLabel.SetText(message)
-}
-
-// Show 显示窗口
-func (ui *ViewerUI) Show() {
-	ui.window.ShowAndRun()
-}
-
-// mustParseURL 解析 URL，如果失败则 panic（用于常量 URL）
-func mustParseURL(urlStr string) *url.URL {
-	parsed, err := url.Parse(urlStr)
-	if err != nil {
-		panic(err)
-	}
-	return parsed
-}
-
-// onShowShortcuts 显示快捷键说明
-func (ui *ViewerUI) onShowShortcuts() {
-	dialog.ShowInformation(ui.tr.DialogShortcutsTitle, ui.tr.DialogShortcutsText, ui.window)
-}
-
-// onShowAbout 显示关于信息
-func (ui *ViewerUI) onShowAbout() {
-	// 创建包含可点击链接的自定义对话框内容
-	var aboutContent fyne.CanvasObject
-
-	if ui.currentLang == LangChinese {
-		// 中文版本
-		aboutContent = container.NewVBox(
-			widget.NewLabel("PDF 阅读器 v1.2.4"),
-			widget.NewLabel(""),
-			widget.NewLabel("基于 Fyne + go-fitz 开发"),
-			widget.NewLabel("简洁、高效的 PDF 阅读工具"),
-			widget.NewLabel(""),
-			container.NewHBox(
-				widget.NewLabel("GitHub:"),
-				widget.NewHyperlink("https://github.com/tools4daily/pdfviewer",
-					mustParseURL("https://github.com/tools4daily/pdfviewer")),
-			),
-			widget.NewLabel(""),
-			widget.NewLabel("开源许可:"),
-			widget.NewLabel("- Fyne: BSD-3-Clause"),
-			widget.NewLabel("- go-fitz: AGPL-3.0"),
-		)
-	} else {
-		// 英文版本
-		aboutContent = container.NewVBox(
-			widget.NewLabel("PDF Reader v1.2.4"),
-			widget.NewLabel(""),
-			widget.NewLabel("Built with Fyne + go-fitz"),
-			widget.NewLabel("A simple and efficient PDF reading tool"),
-			widget.NewLabel(""),
-			container.NewHBox(
-				widget.NewLabel("GitHub:"),
-				widget.NewHyperlink("https://github.com/tools4daily/pdfviewer",
-					mustParseURL("https://github.com/tools4daily/pdfviewer")),
-			),
-			widget.NewLabel(""),
-			widget.NewLabel("Open Source Licenses:"),
-			widget.NewLabel("- Fyne: BSD-3-Clause"),
-			widget.NewLabel("- go-fitz: AGPL-3.0"),
-		)
-	}
-
-	// 创建自定义对话框
-	d := dialog.NewCustom(ui.tr.DialogAboutTitle, "OK", aboutContent, ui.window)
-	d.Show()
-}
-
-// switchLanguage 切换语言
-func (ui *ViewerUI) switchLanguage(lang Language) {
-	if ui.currentLang == lang {
-		return // 已经是当前语言，无需切换
-	}
-
-	// 更新语言设置
-	ui.currentLang = lang
-	ui.tr = GetTranslations(lang)
-
-	// 更新窗口标题
-	ui.window.SetTitle(ui.tr.WindowTitle)
-
-	// 重建菜单栏
-	ui.window.SetMainMenu(ui.createMenuBar())
-
-	// 更新页码输入框提示
-	ui.pageEntry.SetPlaceHolder(ui.tr.HintPageEntry)
-
-	// 更新状态栏
-	ui.updateStatusBar()
-
-	// 更新缩放标签
-	ui.updateZoomLabel()
-
-	// 刷新窗口
-	ui.window.Canvas().Refresh(ui.window.Content())
-}
-
-// scrollableCanvas 支持滚轮翻页的自定义 widget
-type scrollableCanvas struct {
-	widget.BaseWidget
-	content      fyne.CanvasObject
-	onScroll     func(scrolled *fyne.ScrollEvent)
-	onDoubleTap  func()
-}
-
-func newScrollableCanvas(content fyne.CanvasObject, onScroll func(*fyne.ScrollEvent), onDoubleTap func()) *scrollableCanvas {
-	sc := &scrollableCanvas{
-		content:     content,
-		onScroll:    onScroll,
-		onDoubleTap: onDoubleTap,
-	}
-	sc.ExtendBaseWidget(sc)
-	return sc
-}
-
-func (sc *scrollableCanvas) CreateRenderer() fyne.WidgetRenderer {
-	return &scrollableCanvasRenderer{
-		canvas:  sc,
-		content: sc.content,
-	}
-}
-
-func (sc *scrollableCanvas) Scrolled(ev *fyne.ScrollEvent) {
-	if sc.onScroll != nil {
-		sc.onScroll(ev)
-	}
-}
-
-func (sc *scrollableCanvas) DoubleTapped(ev *fyne.PointEvent) {
-	if sc.onDoubleTap != nil {
-		sc.onDoubleTap()
-	}
-}
-
-func (sc *scrollableCanvas) Tapped(ev *fyne.PointEvent) {
-	// 单击事件不处理，仅响应双击
-}
-
-type scrollableCanvasRenderer struct {
-	canvas  *scrollableCanvas
-	content fyne.CanvasObject
-}
-
-func (r *scrollableCanvasRenderer) Layout(size fyne.Size) {
-	r.content.Resize(size)
-}
-
-func (r *scrollableCanvasRenderer) MinSize() fyne.Size {
-	return r.content.MinSize()
-}
-
-func (r *scrollableCanvasRenderer) Refresh() {
-	canvas.Refresh(r.content)
-}
-
-func (r *scrollableCanvasRenderer) Objects() []fyne.CanvasObject {
-	return []fyne.CanvasObject{r.content}
-}
-
-func (r *scrollableCanvasRenderer) Destroy() {}
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ViewerUI PDF 阅读器界面
+type ViewerUI struct {
+	app          fyne.App
+	window       fyne.Window
+	tabContainer *container.AppTabs
+	tabs         []*PDFTab
+	statusLabel  *widget.Label
+	pageEntry    *widget.Entry
+	zoomLabel    *widget.Button // 显示缩放比例的按钮
+	currentLang  Language       // 当前语言
+	tr           *Translations  // 翻译文本
+	editor       string         // 反向搜索使用的编辑器命令，%f 为源文件，%l 为行号
+
+	// 预取设置，应用到每个标签页的控制器
+	prefetchCount       int
+	prefetchDirectional bool
+
+	// 搜索栏
+	searchBar   *fyne.Container
+	searchEntry *searchEntry
+	searchCase  *widget.Check
+	searchWord  *widget.Check
+	searchRegex *widget.Check
+
+	outlineMenuItem    *fyne.MenuItem              // 查看菜单中的“目录”开关
+	continuousMenuItem *fyne.MenuItem              // 查看菜单中的“连续滚动”开关
+	thumbnailMenuItem  *fyne.MenuItem              // 查看菜单中的“缩略图”开关
+	spreadMenuItem     *fyne.MenuItem              // 查看菜单中的“双页显示”开关
+	coverMenuItem      *fyne.MenuItem              // 查看菜单中的“封面单独显示”开关
+	fitMenuItems       map[ZoomMode]*fyne.MenuItem // 查看菜单中的适合宽度/页面/高度
+}
+
+// PDFTab 表示单个 PDF 标签页
+type PDFTab struct {
+	controller    *Controller
+	imageCanvas   *canvas.Image
+	scrollView    *container.Scroll
+	viewStack     *fyne.Container // 单页视图或连续视图
+	loadingLabel  *widget.Label
+	canvasWrapper *scrollableCanvas
+	tabItem       *container.TabItem
+	overlay       *pageOverlay      // 页面高亮叠加层
+	outline       *outlineSidebar   // 目录侧边栏
+	thumbnails    *thumbnailSidebar // 缩略图侧边栏
+	continuous    *continuousView   // 连续滚动视图，nil 表示单页模式
+	spreadCover   bool              // 双页模式下封面是否单独显示
+	refitTimer    *time.Timer       // 视图区尺寸变化后延迟重新适配缩放
+	watcher       *fileWatcher      // 监视本地文件的改写，nil 表示不自动重新加载
+	reloadErr     error             // 最近一次自动重新加载的错误
+	onLoaded      func(tab *PDFTab) // 文档加载成功后执行一次（例如命令行指定的正向搜索）
+
+	placements   map[int]pagePlacement // 当前图像中各页的位置和旋转，双页模式下有两页
+	displayedDPI int                   // 当前显示图像的 DPI
+	search       *searchState
+	scrollTarget *SearchHit // 渲染完成后需要滚动到的命中
+	links        []PageLink // 当前显示页上的链接（图像坐标）
+	hoverLink    *PageLink  // 鼠标下方的链接
+	syncBoxes    []SyncBox  // 正向搜索找到的源文件行，高亮显示
+}
+
+// NewViewerUI 创建界面实例
+func NewViewerUI(app fyne.App, _ *Controller) *ViewerUI {
+	ui := &ViewerUI{
+		app:                 app,
+		tabs:                []*PDFTab{},
+		currentLang:         LangEnglish, // 默认英文
+		tr:                  GetTranslations(LangEnglish),
+		prefetchCount:       defaultPrefetchCount,
+		prefetchDirectional: true,
+	}
+
+	window := app.NewWindow(ui.tr.WindowTitle)
+	window.Resize(fyne.NewSize(900, 700))
+
+	// 设置窗口图标
+	window.SetIcon(getAppIcon())
+
+	ui.window = window
+
+	ui.buildUI()
+	ui.setupKeyBindings()
+
+	// 创建初始标签页（空页面）
+	ui.addNewTab("")
+
+	return ui
+}
+
+// NewPDFTab 创建新的 PDF 标签页
+func NewPDFTab(ui *ViewerUI, filePath string) *PDFTab {
+	tab := &PDFTab{
+		controller: NewController(),
+	}
+	tab.controller.SetPrefetch(ui.prefetchCount, ui.prefetchDirectional)
+
+	// 创建标签页内容
+	content := tab.createContent(ui)
+
+	// 创建标签页项
+	tabName := "新建标签"
+	if filePath != "" {
+		tabName = getFileName(filePath)
+	}
+
+	tab.tabItem = container.NewTabItem(tabName, content)
+
+	// 如果指定了文件路径，加载文件
+	if filePath != "" {
+		go func() {
+			tab.loadPDF(filePath, ui)
+		}()
+	}
+
+	return tab
+}
+
+// createContent 创建标签页内容
+func (tab *PDFTab) createContent(ui *ViewerUI) fyne.CanvasObject {
+	// 中央显示区
+	tab.imageCanvas = canvas.NewImageFromImage(nil)
+	tab.imageCanvas.FillMode = canvas.ImageFillOriginal // 使用原始大小，支持缩放
+
+	tab.loadingLabel = widget.NewLabel(ui.tr.MsgDoubleClickOpen)
+	tab.loadingLabel.Alignment = fyne.TextAlignCenter
+
+	tab.overlay = newPageOverlay()
+
+	centerContent := container.NewStack(
+		tab.imageCanvas,
+		tab.overlay,
+		container.NewCenter(tab.loadingLabel),
+	)
+
+	// 创建支持滚轮、单击、双击和悬停的 canvas wrapper
+	tab.canvasWrapper = newScrollableCanvas(
+		centerContent,
+		func(ev *fyne.ScrollEvent) { tab.onScrollWheel(ev, ui) },
+		func() { tab.onDoubleTap(ui) },
+		func(ev *fyne.PointEvent) { tab.onTap(ev, ui) },
+		func(pos fyne.Position) { tab.onHover(pos, ui) },
+	)
+
+	tab.scrollView = container.NewScroll(tab.canvasWrapper)
+
+	// 目录和缩略图侧边栏，点击条目跳转到对应页
+	navigate := func(page int) {
+		if err := tab.controller.GoToPage(page); err == nil {
+			tab.renderPage(ui)
+			ui.updateStatusBar()
+		}
+	}
+	tab.outline = newOutlineSidebar(ui.tr.MsgNoOutline, navigate)
+	tab.thumbnails = newThumbnailSidebar(navigate, tab.controller.GetRenderOptions, func() float32 {
+		return ui.window.Canvas().Scale()
+	})
+
+	// 视图区尺寸变化（窗口缩放、侧边栏开关）时按自动适配模式重新计算缩放
+	tab.viewStack = container.New(&resizeLayout{onResize: func(fyne.Size) {
+		tab.scheduleRefit(ui)
+	}}, tab.scrollView)
+
+	sidebars := container.NewHBox(tab.outline.container, tab.thumbnails.container)
+	return container.NewBorder(nil, nil, sidebars, nil, tab.viewStack)
+}
+
+// getFileName 从完整路径提取文件名
+func getFileName(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) > 0 {
+		name := parts[len(parts)-1]
+		if len(name) > 20 {
+			return name[:17] + "..."
+		}
+		return name
+	}
+	return "PDF 文档"
+}
+
+// tabTitle 生成标签页标题：文件名加上识别出的格式
+func tabTitle(name string, format DocumentFormat) string {
+	return fmt.Sprintf("%s [%s]", getFileName(name), format)
+}
+
+// buildUI 构建界面
+func (ui *ViewerUI) buildUI() {
+	// 菜单栏
+	ui.window.SetMainMenu(ui.createMenuBar())
+
+	// 顶部工具栏
+	toolbar := ui.createToolbar()
+
+	// 创建标签页容器
+	ui.tabContainer = container.NewAppTabs()
+	ui.tabContainer.SetTabLocation(container.TabLocationTop)
+
+	// 监听标签页切换事件
+	ui.tabContainer.OnSelected = func(tab *container.TabItem) {
+		ui.updateStatusBar()
+		ui.updateZoomLabel()
+		ui.updateViewMenu()
+	}
+
+	// 底部状态栏
+	statusBar := ui.createStatusBar()
+
+	// 搜索栏位于工具栏下方
+	topBar := container.NewVBox(toolbar, ui.createSearchBar())
+
+	// 组合布局
+	content := container.NewBorder(
+		topBar,
+		statusBar,
+		nil, nil,
+		ui.tabContainer,
+	)
+
+	ui.window.SetContent(content)
+}
+
+// addNewTab 添加新标签页
+func (ui *ViewerUI) addNewTab(filePath string) {
+	tab := NewPDFTab(ui, filePath)
+	ui.tabs = append(ui.tabs, tab)
+	ui.tabContainer.Append(tab.tabItem)
+	ui.tabContainer.Select(tab.tabItem)
+	ui.updateStatusBar()
+}
+
+// getCurrentTab 获取当前激活的标签页
+func (ui *ViewerUI) getCurrentTab() *PDFTab {
+	if len(ui.tabs) == 0 {
+		return nil
+	}
+
+	selectedItem := ui.tabContainer.Selected()
+	for _, tab := range ui.tabs {
+		if tab.tabItem == selectedItem {
+			return tab
+		}
+	}
+
+	return nil
+}
+
+// closeCurrentTab 关闭当前标签页
+func (ui *ViewerUI) closeCurrentTab() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	// 找到并移除标签页
+	for i, tab := range ui.tabs {
+		if tab == currentTab {
+			// 关闭 PDF 引擎
+			tab.clearSearch()
+			if tab.continuous != nil {
+				tab.continuous.Stop()
+			}
+			tab.thumbnails.Stop()
+			tab.stopWatching()
+			tab.controller.Close()
+
+			// 从列表中移除
+			ui.tabs = append(ui.tabs[:i], ui.tabs[i+1:]...)
+			ui.tabContainer.Remove(tab.tabItem)
+			break
+		}
+	}
+
+	// 如果没有标签页了，创建一个新的空标签页
+	if len(ui.tabs) == 0 {
+		ui.addNewTab("")
+	}
+
+	ui.updateStatusBar()
+}
+
+// updateStatusBar 更新状态栏
+func (ui *ViewerUI) updateStatusBar() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		ui.statusLabel.SetText(ui.tr.StatusNoDocument)
+		return
+	}
+
+	status := currentTab.controller.GetStatusText(ui.tr)
+	if searchStatus := currentTab.searchStatusText(ui.tr); searchStatus != "" {
+		status += "  |  " + searchStatus
+	}
+	if link := currentTab.hoverLink; link != nil {
+		status += "  |  " + linkStatusText(link, ui.tr)
+	}
+	if err := currentTab.reloadErr; err != nil {
+		status += "  |  " + fmt.Sprintf(ui.tr.StatusReloadError, err)
+	}
+	ui.statusLabel.SetText(status)
+}
+
+// updateZoomLabel 更新缩放标签
+func (ui *ViewerUI) updateZoomLabel() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		ui.zoomLabel.SetText("100%")
+		return
+	}
+
+	zoomPercent := int(currentTab.controller.zoomLevel * 100)
+	ui.zoomLabel.SetText(fmt.Sprintf("%d%%", zoomPercent))
+}
+
+// createMenuBar 创建菜单栏
+func (ui *ViewerUI) createMenuBar() *fyne.MainMenu {
+	// 文件菜单
+	fileMenu := fyne.NewMenu(ui.tr.MenuFile,
+		fyne.NewMenuItem(ui.tr.MenuOpen, ui.onOpenFile),
+		fyne.NewMenuItem(ui.tr.MenuNewTab, func() {
+			ui.addNewTab("")
+		}),
+		fyne.NewMenuItem(ui.tr.MenuSaveAs, ui.onSaveAs),
+		fyne.NewMenuItem(ui.tr.MenuExportPages, ui.onExportPages),
+		fyne.NewMenuItem(ui.tr.MenuExportText, ui.onExportText),
+		fyne.NewMenuItem(ui.tr.MenuProperties, ui.onShowProperties),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuCloseTab, func() {
+			ui.closeCurrentTab()
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuExit, func() {
+			ui.window.Close()
+		}),
+	)
+
+	// 目录侧边栏开关（按标签页记忆）
+	ui.outlineMenuItem = fyne.NewMenuItem(ui.tr.MenuShowOutline, ui.onToggleOutline)
+	if currentTab := ui.getCurrentTab(); currentTab != nil {
+		ui.outlineMenuItem.Checked = currentTab.outline.Visible()
+	}
+	ui.thumbnailMenuItem = fyne.NewMenuItem(ui.tr.MenuThumbnails, ui.onToggleThumbnails)
+	ui.fitMenuItems = map[ZoomMode]*fyne.MenuItem{
+		ZoomFitWidth:  fyne.NewMenuItem(ui.tr.MenuFitWidth, func() { ui.onZoomFit(ZoomFitWidth) }),
+		ZoomFitPage:   fyne.NewMenuItem(ui.tr.MenuFitPage, func() { ui.onZoomFit(ZoomFitPage) }),
+		ZoomFitHeight: fyne.NewMenuItem(ui.tr.MenuFitHeight, func() { ui.onZoomFit(ZoomFitHeight) }),
+	}
+	ui.continuousMenuItem = fyne.NewMenuItem(ui.tr.MenuContinuous, ui.onToggleContinuous)
+	ui.spreadMenuItem = fyne.NewMenuItem(ui.tr.MenuSpread, ui.onToggleSpread)
+	ui.coverMenuItem = fyne.NewMenuItem(ui.tr.MenuSpreadCover, ui.onToggleSpreadCover)
+	if currentTab := ui.getCurrentTab(); currentTab != nil {
+		ui.thumbnailMenuItem.Checked = currentTab.thumbnails.Visible()
+		ui.continuousMenuItem.Checked = currentTab.continuous != nil
+		for mode, item := range ui.fitMenuItems {
+			item.Checked = currentTab.controller.GetZoomMode() == mode
+		}
+		ui.spreadMenuItem.Checked = currentTab.controller.GetSpreadMode() != SpreadOff
+		ui.coverMenuItem.Checked = currentTab.spreadCover
+	}
+
+	// 查看菜单
+	viewMenu := fyne.NewMenu(ui.tr.MenuView,
+		fyne.NewMenuItem(ui.tr.MenuFirstPage, ui.onFirstPage),
+		fyne.NewMenuItem(ui.tr.MenuPrevPage, ui.onPrevPage),
+		fyne.NewMenuItem(ui.tr.MenuNextPage, ui.onNextPage),
+		fyne.NewMenuItem(ui.tr.MenuLastPage, ui.onLastPage),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuZoomIn, ui.onZoomIn),
+		fyne.NewMenuItem(ui.tr.MenuZoomOut, ui.onZoomOut),
+		fyne.NewMenuItem(ui.tr.MenuActualSize, ui.onZoomReset),
+		ui.fitMenuItems[ZoomFitWidth],
+		ui.fitMenuItems[ZoomFitPage],
+		ui.fitMenuItems[ZoomFitHeight],
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuRotatePageCW, func() { ui.onRotate(90, false) }),
+		fyne.NewMenuItem(ui.tr.MenuRotatePageCCW, func() { ui.onRotate(-90, false) }),
+		fyne.NewMenuItem(ui.tr.MenuRotateViewCW, func() { ui.onRotate(90, true) }),
+		fyne.NewMenuItem(ui.tr.MenuRotateViewCCW, func() { ui.onRotate(-90, true) }),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuFind, ui.showSearchBar),
+		ui.outlineMenuItem,
+		ui.thumbnailMenuItem,
+		ui.continuousMenuItem,
+		ui.spreadMenuItem,
+		ui.coverMenuItem,
+		fyne.NewMenuItem(ui.tr.MenuCopyPageText, ui.onCopyPageText),
+		fyne.NewMenuItem(ui.tr.MenuTextLayout, ui.onTextLayout),
+	)
+
+	// 语言菜单
+	langMenu := fyne.NewMenu(ui.tr.MenuLanguage,
+		fyne.NewMenuItem(ui.tr.MenuEnglish, func() {
+			ui.switchLanguage(LangEnglish)
+		}),
+		fyne.NewMenuItem(ui.tr.MenuChinese, func() {
+			ui.switchLanguage(LangChinese)
+		}),
+	)
+
+	// 帮助菜单
+	helpMenu := fyne.NewMenu(ui.tr.MenuHelp,
+		fyne.NewMenuItem(ui.tr.MenuShortcuts, ui.onShowShortcuts),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.tr.MenuAbout, ui.onShowAbout),
+	)
+
+	return fyne.NewMainMenu(fileMenu, viewMenu, langMenu, helpMenu)
+}
+
+// updateViewMenu 根据当前标签页更新查看菜单的勾选状态
+func (ui *ViewerUI) updateViewMenu() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || ui.outlineMenuItem == nil {
+		return
+	}
+
+	ui.outlineMenuItem.Checked = currentTab.outline.Visible()
+	ui.thumbnailMenuItem.Checked = currentTab.thumbnails.Visible()
+	ui.continuousMenuItem.Checked = currentTab.continuous != nil
+	for mode, item := range ui.fitMenuItems {
+		item.Checked = currentTab.controller.GetZoomMode() == mode
+	}
+	ui.spreadMenuItem.Checked = currentTab.controller.GetSpreadMode() != SpreadOff
+	ui.coverMenuItem.Checked = currentTab.spreadCover
+	if menu := ui.window.MainMenu(); menu != nil {
+		menu.Refresh()
+	}
+}
+
+// onToggleOutline 显示/隐藏当前标签页的目录侧边栏
+func (ui *ViewerUI) onToggleOutline() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	currentTab.outline.SetVisible(!currentTab.outline.Visible())
+	ui.updateViewMenu()
+}
+
+// onToggleThumbnails 显示/隐藏当前标签页的缩略图侧边栏
+func (ui *ViewerUI) onToggleThumbnails() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	currentTab.thumbnails.SetVisible(!currentTab.thumbnails.Visible())
+	currentTab.thumbnails.SyncToPage(currentTab.controller.GetCurrentPage())
+	ui.updateViewMenu()
+}
+
+// onToggleContinuous 切换当前标签页的连续滚动模式
+func (ui *ViewerUI) onToggleContinuous() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	currentTab.setContinuous(ui, currentTab.continuous == nil)
+	ui.updateViewMenu()
+}
+
+// setContinuous 在单页视图和连续滚动视图之间切换
+func (tab *PDFTab) setContinuous(ui *ViewerUI, enabled bool) {
+	if enabled == (tab.continuous != nil) {
+		return
+	}
+
+	if enabled {
+		// 连续滚动与双页显示互斥
+		tab.controller.SetSpreadMode(SpreadOff)
+
+		// 用户滚动时同步当前页，但不触发重新滚动
+		onPageChanged := func(page int) {
+			if page == tab.controller.GetCurrentPage() {
+				return
+			}
+			if err := tab.controller.GoToPage(page); err == nil {
+				tab.outline.SyncToPage(page)
+				tab.thumbnails.SyncToPage(page)
+				if tab == ui.getCurrentTab() {
+					ui.updateStatusBar()
+				}
+			}
+		}
+		onTap := func(page int, x, y float64, link *PageLink, modifier fyne.KeyModifier) {
+			tab.onPageTap(page, x, y, link, modifier, ui)
+		}
+		onHover := func(link *PageLink) {
+			tab.hoverLink = link
+			if ui.getCurrentTab() == tab {
+				ui.updateStatusBar()
+			}
+		}
+		tab.continuous = newContinuousView(tab.controller, ui.tr.MsgLoading, onPageChanged, tab.pageHighlights, onTap, onHover)
+		tab.viewStack.Objects = []fyne.CanvasObject{tab.continuous.scroll}
+	} else {
+		tab.continuous.Stop()
+		tab.continuous = nil
+		tab.viewStack.Objects = []fyne.CanvasObject{tab.scrollView}
+	}
+	tab.hoverLink = nil
+	tab.viewStack.Refresh()
+	tab.renderPage(ui)
+}
+
+// onToggleSpread 切换当前标签页的双页显示
+func (ui *ViewerUI) onToggleSpread() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	mode := SpreadOff
+	if currentTab.controller.GetSpreadMode() == SpreadOff {
+		mode = currentTab.spreadModeSetting()
+	}
+	currentTab.applySpreadMode(ui, mode)
+}
+
+// onToggleSpreadCover 切换双页显示时封面是否单独显示
+func (ui *ViewerUI) onToggleSpreadCover() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	currentTab.spreadCover = !currentTab.spreadCover
+	if currentTab.controller.GetSpreadMode() != SpreadOff {
+		currentTab.applySpreadMode(ui, currentTab.spreadModeSetting())
+	} else {
+		ui.updateViewMenu()
+	}
+}
+
+// spreadModeSetting 按封面设置返回开启双页显示时使用的模式
+func (tab *PDFTab) spreadModeSetting() SpreadMode {
+	if tab.spreadCover {
+		return SpreadCover
+	}
+	return SpreadFacing
+}
+
+// applySpreadMode 设置页面排列方式并重新渲染
+func (tab *PDFTab) applySpreadMode(ui *ViewerUI, mode SpreadMode) {
+	tab.controller.SetSpreadMode(mode)
+	if mode != SpreadOff && tab.continuous != nil {
+		tab.setContinuous(ui, false) // 会重新渲染
+	} else {
+		tab.renderPage(ui)
+	}
+	ui.updateStatusBar()
+	ui.updateViewMenu()
+}
+
+// createToolbar 创建工具栏
+func (ui *ViewerUI) createToolbar() fyne.CanvasObject {
+	// 文件按钮
+	openBtn := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), ui.onOpenFile)
+	openBtn.Importance = widget.HighImportance
+
+	// 另存为按钮
+	saveAsBtn := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), ui.onSaveAs)
+
+	// 关闭标签页按钮
+	closeTabBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+		ui.closeCurrentTab()
+	})
+	closeTabBtn.Importance = widget.DangerImportance
+
+	// 导航按钮
+	firstBtn := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), ui.onFirstPage)
+	prevBtn := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), ui.onPrevPage)
+	nextBtn := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), ui.onNextPage)
+	lastBtn := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), ui.onLastPage)
+
+	// 页码跳转
+	ui.pageEntry = widget.NewEntry()
+	ui.pageEntry.SetPlaceHolder(ui.tr.HintPageEntry)
+	ui.pageEntry.OnSubmitted = ui.onPageJump
+
+	// 缩放按钮
+	zoomOutBtn := widget.NewButtonWithIcon("", theme.ZoomOutIcon(), ui.onZoomOut)
+	ui.zoomLabel = widget.NewButton("100%", ui.onZoomReset)
+	zoomInBtn := widget.NewButtonWithIcon("", theme.ZoomInIcon(), ui.onZoomIn)
+
+	// 复制页面文本按钮
+	copyTextBtn := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), ui.onCopyPageText)
+
+	// 组合工具栏
+	toolbar := container.NewHBox(
+		openBtn,
+		saveAsBtn,
+		closeTabBtn,
+		widget.NewSeparator(),
+		firstBtn,
+		prevBtn,
+		ui.pageEntry,
+		nextBtn,
+		lastBtn,
+		widget.NewSeparator(),
+		zoomOutBtn,
+		ui.zoomLabel,
+		zoomInBtn,
+		widget.NewSeparator(),
+		copyTextBtn,
+	)
+
+	return toolbar
+}
+
+// createStatusBar 创建状态栏
+func (ui *ViewerUI) createStatusBar() fyne.CanvasObject {
+	ui.statusLabel = widget.NewLabel(ui.tr.StatusNoDocument)
+	return container.NewPadded(ui.statusLabel)
+}
+
+// setupKeyBindings 设置键盘快捷键
+func (ui *ViewerUI) setupKeyBindings() {
+	// 添加 Ctrl+W 快捷键关闭当前标签
+	ui.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyW,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		ui.closeCurrentTab()
+	})
+
+	// Ctrl+R / Ctrl+Shift+R 顺时针/逆时针旋转当前页
+	ui.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyR,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		ui.onRotate(90, false)
+	})
+	ui.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyR,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}, func(shortcut fyne.Shortcut) {
+		ui.onRotate(-90, false)
+	})
+
+	// Ctrl+F 打开搜索栏
+	ui.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyF,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		ui.showSearchBar()
+	})
+
+	ui.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
+		currentTab := ui.getCurrentTab()
+		if currentTab == nil {
+			return
+		}
+
+		switch key.Name {
+		case fyne.KeyLeft, fyne.KeyPageUp:
+			currentTab.onPrevPage(ui)
+		case fyne.KeyRight, fyne.KeyPageDown, fyne.KeySpace:
+			currentTab.onNextPage(ui)
+		case fyne.KeyHome:
+			currentTab.onFirstPage(ui)
+		case fyne.KeyEnd:
+			currentTab.onLastPage(ui)
+		}
+	})
+}
+
+// PDFTab 的滚轮事件处理
+func (tab *PDFTab) onScrollWheel(ev *fyne.ScrollEvent, ui *ViewerUI) {
+	if !tab.controller.HasDocument() {
+		return
+	}
+
+	if ev.Scrolled.DY < 0 {
+		// 向下滚动 → 下一页
+		if tab.controller.NextPage() {
+			tab.renderPage(ui)
+		}
+	} else if ev.Scrolled.DY > 0 {
+		// 向上滚动 → 上一页
+		if tab.controller.PrevPage() {
+			tab.renderPage(ui)
+		}
+	}
+}
+
+// PDFTab 的单击事件处理：点击链接时跳转或打开外部地址，Ctrl+单击时反向搜索源文件
+func (tab *PDFTab) onTap(ev *fyne.PointEvent, ui *ViewerUI) {
+	if tab.canvasWrapper.modifier&(fyne.KeyModifierControl|fyne.KeyModifierSuper) != 0 {
+		tab.inverseSearch(ev.Position, ui)
+		return
+	}
+
+	if link := tab.linkAt(ev.Position); link != nil {
+		tab.followLink(link, ui)
+	}
+}
+
+// onPageTap 连续模式下单击页面（页面坐标）：点击链接时跳转或打开外部地址，Ctrl+单击时反向搜索源文件
+func (tab *PDFTab) onPageTap(page int, x, y float64, link *PageLink, modifier fyne.KeyModifier, ui *ViewerUI) {
+	if modifier&(fyne.KeyModifierControl|fyne.KeyModifierSuper) != 0 {
+		tab.inverseSearchAt(page, x, y, ui)
+		return
+	}
+	if link != nil {
+		tab.followLink(link, ui)
+	}
+}
+
+// followLink 内部链接跳转到目标页，外部链接确认后用系统浏览器打开
+func (tab *PDFTab) followLink(link *PageLink, ui *ViewerUI) {
+	if link.IsInternal() {
+		if err := tab.controller.GoToPage(link.Page); err == nil {
+			tab.renderPage(ui)
+			ui.updateStatusBar()
+		}
+		return
+	}
+
+	ui.confirmOpenURL(link.URI)
+}
+
+// PDFTab 的鼠标悬停处理：更新光标和状态栏中的链接目标
+func (tab *PDFTab) onHover(pos fyne.Position, ui *ViewerUI) {
+	link := tab.linkAt(pos)
+	if link == tab.hoverLink {
+		return
+	}
+
+	tab.hoverLink = link
+	tab.canvasWrapper.SetPointer(link != nil)
+	if ui.getCurrentTab() == tab {
+		ui.updateStatusBar()
+	}
+}
+
+// linkAt 返回控件坐标处的链接
+func (tab *PDFTab) linkAt(pos fyne.Position) *PageLink {
+	x, y, ok := tab.overlay.PositionToPagePoint(pos)
+	if !ok {
+		return nil
+	}
+
+	for i := range tab.links {
+		if tab.links[i].Contains(x, y) {
+			return &tab.links[i]
+		}
+	}
+	return nil
+}
+
+// inverseSearch 查找控件坐标处对应的源文件行，并交给编辑器打开
+func (tab *PDFTab) inverseSearch(pos fyne.Position, ui *ViewerUI) {
+	if !tab.controller.HasDocument() {
+		return
+	}
+	x, y, ok := tab.overlay.PositionToPagePoint(pos)
+	if !ok {
+		return
+	}
+
+	for page, placement := range tab.placements {
+		if px, py, ok := placement.PagePoint(x, y); ok {
+			tab.inverseSearchAt(page, px, py, ui)
+			return
+		}
+	}
+}
+
+// inverseSearchAt 查找页面坐标处对应的源文件行，并交给编辑器打开
+func (tab *PDFTab) inverseSearchAt(page int, x, y float64, ui *ViewerUI) {
+	if !tab.controller.HasDocument() {
+		return
+	}
+
+	loc, err := tab.controller.InverseSearch(page, x, y)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgSyncTeXFailed, err), ui.window)
+		return
+	}
+	ui.openInEditor(loc)
+}
+
+// openInEditor 用编辑器命令打开源文件位置，未设置编辑器时只显示位置
+func (ui *ViewerUI) openInEditor(loc SourceLocation) {
+	if ui.editor == "" {
+		dialog.ShowInformation(ui.tr.DialogSyncTeXTitle, fmt.Sprintf(ui.tr.MsgSyncTeXSource, loc), ui.window)
+		return
+	}
+
+	cmd, err := editorCommand(ui.editor, loc)
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgEditorFailed, err), ui.window)
+		return
+	}
+	go cmd.Wait() // 回收编辑器进程
+}
+
+// forwardSearch 跳转到源文件位置对应的页面并高亮对应的行
+func (tab *PDFTab) forwardSearch(loc SourceLocation, ui *ViewerUI) {
+	boxes, err := tab.controller.ForwardSearch(loc)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgSyncTeXFailed, err), ui.window)
+		return
+	}
+
+	tab.syncBoxes = boxes
+	tab.scrollTarget = &SearchHit{Page: boxes[0].Page, Rect: boxes[0].Rect}
+	tab.renderPage(ui)
+	if tab == ui.getCurrentTab() {
+		ui.updateStatusBar()
+	}
+}
+
+// syncHighlights 返回显示页上正向搜索找到的行，imageRect 将页面坐标换算为图像坐标
+func (tab *PDFTab) syncHighlights(imageRect func(page int, rect PageRect) (PageRect, bool)) []overlayRect {
+	var rects []overlayRect
+	for _, box := range tab.syncBoxes {
+		if rect, shown := imageRect(box.Page, box.Rect); shown {
+			rects = append(rects, overlayRect{rect: rect, color: syncHighlightColor})
+		}
+	}
+	return rects
+}
+
+// linkStatusText 返回状态栏上的链接目标描述
+func linkStatusText(link *PageLink, tr *Translations) string {
+	if link.IsInternal() {
+		return fmt.Sprintf(tr.StatusLinkPage, link.Page)
+	}
+	return link.URI
+}
+
+// confirmOpenURL 确认后用系统浏览器打开外部链接
+func (ui *ViewerUI) confirmOpenURL(uri string) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgInvalidLink, uri), ui.window)
+		return
+	}
+
+	dialog.ShowConfirm(ui.tr.DialogOpenLinkTitle, fmt.Sprintf(ui.tr.MsgOpenLinkConfirm, uri), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := ui.app.OpenURL(u); err != nil {
+			dialog.ShowError(err, ui.window)
+		}
+	}, ui.window)
+}
+
+// PDFTab 的双击事件处理
+func (tab *PDFTab) onDoubleTap(ui *ViewerUI) {
+	// 仅在未打开文档时响应双击
+	if !tab.controller.HasDocument() {
+		ui.onOpenFile()
+	}
+}
+
+// openFileInCurrentTab 在当前标签页打开文件
+func (ui *ViewerUI) openFileInCurrentTab(filePath string) {
+	ui.openSourceInCurrentTab(fileSource(filePath))
+}
+
+// openSourceInCurrentTab 在当前标签页打开文档来源
+func (ui *ViewerUI) openSourceInCurrentTab(src documentSource) {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		go func() {
+			currentTab.loadSource(src, "", ui)
+		}()
+	}
+}
+
+// openArgInCurrentTab 在当前标签页打开命令行参数指定的文档（文件路径、URI 或 - 表示标准输入）
+// onLoaded 不为 nil 时在文档加载成功后执行；返回的通道在加载结束后收到打开错误
+func (ui *ViewerUI) openArgInCurrentTab(arg, password string, onLoaded func(tab *PDFTab)) <-chan error {
+	done := make(chan error, 1)
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		done <- errors.New("没有可用的标签页")
+		return done
+	}
+	currentTab.onLoaded = onLoaded
+
+	// 标准输入和远程 URI 可能需要较长时间读取，在后台执行
+	currentTab.showLoading(ui.tr.MsgLoading)
+	go func() {
+		src, err := argSource(arg)
+		if err != nil {
+			currentTab.showError(fmt.Sprintf(ui.tr.MsgLoadFailed, err))
+			done <- err
+			return
+		}
+		done <- currentTab.loadSource(src, password, ui)
+	}()
+	return done
+}
+
+// openRequest 打开命令行或其他进程转交的文档，加载后跳页、正向搜索和搜索文本
+// 文档已在某个标签页中打开时切换到该标签页，不重新加载
+// 请求中的密码只用于这一个文档，之后打开的文档不会沿用
+// 返回的通道在文档加载结束后收到打开错误（成功时为 nil），转交请求的进程据此设置退出码
+func (ui *ViewerUI) openRequest(req openRequest) <-chan error {
+	done := make(chan error, 1)
+	var forward *SourceLocation
+	if req.Forward != "" {
+		loc, err := ParseSourceLocation(req.Forward)
+		if err != nil {
+			done <- err
+			return done
+		}
+		forward = &loc
+	}
+	if req.File == "" {
+		done <- nil
+		return done
+	}
+
+	onLoaded := func(tab *PDFTab) {
+		if req.Page > 0 && tab.controller.GoToPage(req.Page) == nil {
+			tab.renderPage(ui)
+			ui.updateStatusBar()
+		}
+		if forward != nil {
+			tab.forwardSearch(*forward, ui)
+		}
+		if req.Search != "" {
+			// 搜索状态只在界面协程中读写，onLoaded 可能在加载协程中执行
+			fyne.Do(func() {
+				if tab == ui.getCurrentTab() {
+					ui.searchBar.Show()
+					ui.searchEntry.SetText(req.Search)
+					ui.onSearch(true)
+				}
+			})
+		}
+	}
+
+	for _, tab := range ui.tabs {
+		if engine := tab.controller.Engine(); engine != nil && samePath(engine.GetFilePath(), req.File) {
+			ui.tabContainer.Select(tab.tabItem)
+			onLoaded(tab)
+			done <- nil
+			return done
+		}
+	}
+
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || currentTab.controller.HasDocument() {
+		ui.addNewTab("")
+	}
+	return ui.openArgInCurrentTab(req.File, req.Password, onLoaded)
+}
+
+// samePath 判断两个本地路径是否指向同一文件（统一转换为绝对路径后比较），空路径不与任何路径相同
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
+
+// openSource 打开文档来源：当前标签页为空时直接使用，否则新建标签页
+func (ui *ViewerUI) openSource(src documentSource) {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || currentTab.controller.HasDocument() {
+		ui.addNewTab("")
+	}
+	ui.openSourceInCurrentTab(src)
+}
+
+// onSaveAs 另存为
+func (ui *ViewerUI) onSaveAs() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		dialog.ShowInformation(ui.tr.MenuHelp, ui.tr.MsgNoDocumentToSave, ui.window)
+		return
+	}
+
+	engine := currentTab.controller.Engine()
+
+	// 创建保存对话框
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+
+		// 通过 writer 写入，支持任意存储 URI 和从内存打开的文档
+		err = engine.SaveTo(writer)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(ui.tr.MsgSaveFailed, err), ui.window)
+			return
+		}
+
+		dialog.ShowInformation(ui.tr.MenuHelp, ui.tr.MsgSaveSuccess, ui.window)
+	}, ui.window)
+
+	// 设置默认文件名，按原文件的扩展名过滤
+	fileName := engine.GetFileName()
+	saveDialog.SetFileName(fileName)
+	if ext := strings.ToLower(filepath.Ext(fileName)); ext != "" {
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{ext}))
+	}
+	saveDialog.Show()
+}
+
+// onOpenFile 打开文件对话框
+func (ui *ViewerUI) onOpenFile() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+
+		// 非本地文件需要读取全部内容，在后台执行
+		go func() {
+			defer reader.Close()
+
+			src, err := readerSource(reader)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(ui.tr.MsgLoadFailed, err), ui.window)
+				return
+			}
+			ui.openSource(src)
+		}()
+	}, ui.window)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter(supportedExtensions()))
+	fileDialog.Show()
+}
+
+// loadPDF 加载 PDF 文件（PDFTab 方法）
+func (tab *PDFTab) loadPDF(filePath string, ui *ViewerUI) {
+	tab.loadSource(fileSource(filePath), "", ui)
+}
+
+// loadSource 使用密码加载文档，加密文档需要密码时弹出密码对话框
+// 返回打开错误，错误已在标签页或密码对话框中提示
+func (tab *PDFTab) loadSource(src documentSource, password string, ui *ViewerUI) error {
+	tab.showLoading(ui.tr.MsgLoading)
+
+	fyne.Do(tab.clearSearch)
+	err := src.open(tab.controller, password)
+	switch {
+	case errors.Is(err, ErrPasswordRequired):
+		tab.askPassword(src, ui.tr.MsgPasswordRequired, ui)
+		return err
+	case errors.Is(err, ErrWrongPassword):
+		tab.askPassword(src, ui.tr.MsgWrongPassword, ui)
+		return err
+	case errors.Is(err, ErrCorruptFile):
+		tab.showError(fmt.Sprintf(ui.tr.MsgCorruptFile, err))
+		return err
+	case err != nil:
+		tab.showError(fmt.Sprintf(ui.tr.MsgLoadFailed, err))
+		return err
+	}
+
+	// 更新标签页标题，本地文件被改写后自动重新加载
+	tab.reloadErr = nil
+	tab.syncBoxes = nil
+	tab.updateTitle(ui)
+	tab.watchFile(src.path, ui)
+
+	// 加载目录，读取失败时按无目录处理
+	outline, err := tab.controller.GetOutline()
+	if err != nil {
+		outline = nil
+	}
+	tab.outline.SetItems(outline)
+	tab.thumbnails.SetDocument(tab.controller.engine)
+
+	tab.renderPage(ui)
+	ui.updateStatusBar()
+	ui.updateZoomLabel()
+
+	if onLoaded := tab.onLoaded; onLoaded != nil {
+		tab.onLoaded = nil
+		onLoaded(tab)
+	}
+	return nil
+}
+
+// updateTitle 更新标签页标题，自动重新加载失败时显示警告图标
+func (tab *PDFTab) updateTitle(ui *ViewerUI) {
+	tab.tabItem.Text = tabTitle(tab.controller.engine.GetFileName(), tab.controller.GetFormat())
+	tab.tabItem.Icon = nil
+	if tab.reloadErr != nil {
+		tab.tabItem.Icon = theme.WarningIcon()
+	}
+	ui.tabContainer.Refresh()
+}
+
+// watchFile 监视本地文件，从内存打开的文档不监视；无法监视时只是不自动重新加载
+func (tab *PDFTab) watchFile(path string, ui *ViewerUI) {
+	tab.stopWatching()
+	if path == "" {
+		return
+	}
+
+	watcher, err := newFileWatcher(path, func() {
+		tab.reload(ui)
+	})
+	if err != nil {
+		return
+	}
+	tab.watcher = watcher
+}
+
+// stopWatching 停止监视文件
+func (tab *PDFTab) stopWatching() {
+	if tab.watcher != nil {
+		tab.watcher.Close()
+		tab.watcher = nil
+	}
+}
+
+// reload 文件在磁盘上改变后重新打开，保留页码、缩放和旋转
+// 由文件监视协程调用：新版本在后台打开，再回到界面协程替换文档并更新界面，
+// 其间界面和渲染协程继续使用旧文档。失败时（例如文件仍在写入或已损坏）继续显示旧版本，下次改写时再试
+func (tab *PDFTab) reload(ui *ViewerUI) {
+	fyne.Do(func() {
+		current := tab.controller.Engine()
+		if current == nil || tab.watcher == nil {
+			return // 标签页已关闭
+		}
+
+		go func() {
+			engine, err := reopenDocument(current)
+			fyne.Do(func() {
+				tab.applyReload(ui, current, engine, err)
+			})
+		}()
+	})
+}
+
+// applyReload 在界面协程中换上重新打开的文档（打开失败时 engine 为 nil）
+func (tab *PDFTab) applyReload(ui *ViewerUI, current, engine *PDFEngine, err error) {
+	if tab.watcher == nil || tab.controller.Engine() != current {
+		// 重新打开期间标签页已关闭或打开了其他文档
+		if engine != nil {
+			engine.Close()
+		}
+		return
+	}
+
+	tab.reloadErr = err
+	if err == nil {
+		tab.controller.ApplyReload(current, engine)
+
+		// 页数和内容可能都变了，目录、缩略图和搜索结果需要重新生成
+		outline, err := tab.controller.GetOutline()
+		if err != nil {
+			outline = nil
+		}
+		tab.clearSearch()
+		tab.syncBoxes = nil
+		tab.outline.SetItems(outline)
+		tab.thumbnails.SetDocument(tab.controller.engine)
+		tab.renderPage(ui)
+	}
+	tab.updateTitle(ui)
+
+	if tab == ui.getCurrentTab() {
+		ui.updateStatusBar()
+	}
+}
+
+// askPassword 弹出密码对话框，取消时在标签页中显示提示
+func (tab *PDFTab) askPassword(src documentSource, message string, ui *ViewerUI) {
+	tab.showError(ui.tr.MsgPasswordCancelled)
+
+	passwordEntry := widget.NewPasswordEntry()
+	items := []*widget.FormItem{
+		widget.NewFormItem("", widget.NewLabel(fmt.Sprintf(message, src.name))),
+		widget.NewFormItem(ui.tr.LabelPassword, passwordEntry),
+	}
+
+	d := dialog.NewForm(ui.tr.DialogPasswordTitle, "OK", ui.tr.ButtonCancel, items, func(ok bool) {
+		if !ok {
+			tab.showError(ui.tr.MsgPasswordCancelled)
+			return
+		}
+		password := passwordEntry.Text
+		go func() {
+			tab.loadSource(src, password, ui)
+		}()
+	}, ui.window)
+	passwordEntry.OnSubmitted = func(string) { d.Submit() }
+	d.Show()
+	ui.window.Canvas().Focus(passwordEntry)
+}
+
+// onFirstPage 跳转到首页
+func (ui *ViewerUI) onFirstPage() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onFirstPage(ui)
+	}
+}
+
+func (tab *PDFTab) onFirstPage(ui *ViewerUI) {
+	if tab.controller.FirstPage() {
+		tab.renderPage(ui)
+		ui.updateStatusBar()
+	}
+}
+
+// onPrevPage 上一页
+func (ui *ViewerUI) onPrevPage() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onPrevPage(ui)
+	}
+}
+
+func (tab *PDFTab) onPrevPage(ui *ViewerUI) {
+	if tab.controller.PrevPage() {
+		tab.renderPage(ui)
+		ui.updateStatusBar()
+	}
+}
+
+// onNextPage 下一页
+func (ui *ViewerUI) onNextPage() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onNextPage(ui)
+	}
+}
+
+func (tab *PDFTab) onNextPage(ui *ViewerUI) {
+	if tab.controller.NextPage() {
+		tab.renderPage(ui)
+		ui.updateStatusBar()
+	}
+}
+
+// onLastPage 跳转到末页
+func (ui *ViewerUI) onLastPage() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onLastPage(ui)
+	}
+}
+
+func (tab *PDFTab) onLastPage(ui *ViewerUI) {
+	if tab.controller.LastPage() {
+		tab.renderPage(ui)
+		ui.updateStatusBar()
+	}
+}
+
+// onPageJump 页码跳转
+func (ui *ViewerUI) onPageJump(text string) {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	pageNum, err := strconv.Atoi(text)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(ui.tr.MsgInvalidPage), ui.window)
+		return
+	}
+
+	err = currentTab.controller.GoToPage(pageNum)
+	if err != nil {
+		dialog.ShowError(err, ui.window)
+		return
+	}
+
+	currentTab.renderPage(ui)
+	ui.updateStatusBar()
+}
+
+// onZoomIn 放大
+func (ui *ViewerUI) onZoomIn() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onZoomIn(ui)
+	}
+}
+
+func (tab *PDFTab) onZoomIn(ui *ViewerUI) {
+	tab.controller.ZoomIn()
+	tab.renderPage(ui)
+	ui.updateStatusBar()
+	ui.updateZoomLabel()
+	ui.updateViewMenu()
+}
+
+// onZoomOut 缩小
+func (ui *ViewerUI) onZoomOut() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onZoomOut(ui)
+	}
+}
+
+func (tab *PDFTab) onZoomOut(ui *ViewerUI) {
+	tab.controller.ZoomOut()
+	tab.renderPage(ui)
+	ui.updateStatusBar()
+	ui.updateZoomLabel()
+	ui.updateViewMenu()
+}
+
+// onZoomReset 重置缩放
+func (ui *ViewerUI) onZoomReset() {
+	currentTab := ui.getCurrentTab()
+	if currentTab != nil {
+		currentTab.onZoomReset(ui)
+	}
+}
+
+func (tab *PDFTab) onZoomReset(ui *ViewerUI) {
+	tab.controller.ResetZoom()
+	tab.renderPage(ui)
+	ui.updateStatusBar()
+	ui.updateZoomLabel()
+	ui.updateViewMenu()
+}
+
+// onZoomFit 切换适合宽度/页面/高度，再次选择当前模式时恢复手动缩放
+func (ui *ViewerUI) onZoomFit(mode ZoomMode) {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil {
+		return
+	}
+
+	if currentTab.controller.GetZoomMode() == mode {
+		mode = ZoomCustom
+	}
+	currentTab.controller.SetZoomMode(mode)
+	currentTab.renderPage(ui)
+	ui.updateStatusBar()
+	ui.updateZoomLabel()
+	ui.updateViewMenu()
+}
+
+// onRotate 旋转当前页（双页模式下为两页）或整个视图，delta 为正时顺时针
+func (ui *ViewerUI) onRotate(delta int, wholeView bool) {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		return
+	}
+
+	if wholeView {
+		currentTab.controller.RotateView(delta)
+	} else {
+		currentTab.controller.RotatePages(delta)
+	}
+	currentTab.thumbnails.Refresh()
+	currentTab.renderPage(ui)
+	ui.updateStatusBar()
+}
+
+// scheduleRefit 视图区尺寸变化后延迟重新适配，避免拖动窗口边缘时反复渲染
+func (tab *PDFTab) scheduleRefit(ui *ViewerUI) {
+	if tab.controller.GetZoomMode() == ZoomCustom {
+		return
+	}
+
+	if tab.refitTimer != nil {
+		tab.refitTimer.Stop()
+	}
+	tab.refitTimer = time.AfterFunc(refitDelay, func() {
+		if !tab.fitToViewport(ui) {
+			return
+		}
+		tab.renderPage(ui)
+		if tab == ui.getCurrentTab() {
+			ui.updateStatusBar()
+			ui.updateZoomLabel()
+		}
+	})
+}
+
+// fitToViewport 按视图区的像素尺寸重新计算自动适配的缩放级别，DPI 改变时返回 true
+func (tab *PDFTab) fitToViewport(ui *ViewerUI) bool {
+	size := tab.viewStack.Size()
+	scale := ui.window.Canvas().Scale()
+
+	// 留出边距，避免适配后出现滚动条
+	margin := 2 * theme.Padding()
+	width := float64((size.Width - margin) * scale)
+	height := float64((size.Height - margin) * scale)
+	return tab.controller.FitToViewport(width, height)
+}
+
+// onCopyPageText 复制当前页面文本到剪贴板
+func (ui *ViewerUI) onCopyPageText() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		return
+	}
+
+	// 文本提取可能较慢，在后台执行
+	go func() {
+		text, err := currentTab.controller.GetCurrentPageText()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(ui.tr.MsgExtractTextFailed, err), ui.window)
+			return
+		}
+
+		if strings.TrimSpace(text) == "" {
+			dialog.ShowInformation(ui.tr.MenuCopyPageText, ui.tr.MsgNoPageText, ui.window)
+			return
+		}
+
+		ui.window.Clipboard().SetContent(text)
+		dialog.ShowInformation(ui.tr.MenuCopyPageText, ui.tr.MsgTextCopied, ui.window)
+	}()
+}
+
+// layoutPageSizes 文字排版对话框中可选的版面大小（点），第一项为 MuPDF 的默认版面
+var layoutPageSizes = []struct {
+	name          string
+	width, height float64
+}{
+	{"450 × 600", defaultLayoutWidth, defaultLayoutHeight},
+	{"320 × 480", 320, 480},
+	{"A5 (420 × 595)", 420, 595},
+	{"A4 (595 × 842)", 595, 842},
+	{"Letter (612 × 792)", 612, 792},
+}
+
+// onTextLayout 调整可重排文档（EPUB、FB2、MOBI）的版面大小和字号
+func (ui *ViewerUI) onTextLayout() {
+	currentTab := ui.getCurrentTab()
+	if currentTab == nil || !currentTab.controller.HasDocument() {
+		return
+	}
+
+	engine := currentTab.controller.Engine()
+	if !engine.GetFormat().Reflowable() {
+		dialog.ShowInformation(ui.tr.DialogLayoutTitle, ui.tr.MsgLayoutUnsupported, ui.window)
+		return
+	}
+
+	layout := engine.GetLayout()
+	pageNames := make([]string, len(layoutPageSizes))
+	pageSelect := widget.NewSelect(nil, nil)
+	for i, size := range layoutPageSizes {
+		pageNames[i] = size.name
+		if size.width == layout.PageWidth && size.height == layout.PageHeight {
+			pageSelect.Selected = size.name
+		}
+	}
+	pageSelect.Options = pageNames
+	if pageSelect.Selected == "" {
+		pageSelect.Selected = pageNames[0]
+	}
+
+	sizes := []string{"8", "10", "12", "14", "16", "18", "20", "24", "28", "32"}
+	sizeSelect := widget.NewSelect(sizes, nil)
+	sizeSelect.SetSelected(strconv.FormatFloat(layout.FontSize, 'f', -1, 64))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(ui.tr.LabelPageSize, pageSelect),
+		widget.NewFormItem(ui.tr.LabelFontSize, sizeSelect),
+	}
+	dialog.ShowForm(ui.tr.DialogLayoutTitle, "OK", ui.tr.ButtonCancel, items, func(ok bool) {
+		if !ok {
+			return
+		}
+		fontSize, err := strconv.ParseFloat(sizeSelect.Selected, 64)
+		if err != nil {
+			return
+		}
+		opts := LayoutOptions{FontSize: fontSize}
+		for _, size := range layoutPageSizes {
+			if size.name == pageSelect.Selected {
+				opts.PageWidth, opts.PageHeight = size.width, size.height
+			}
+		}
+
+		// 重新排版整本书可能较慢，在后台执行
+		currentTab.showLoading(ui.tr.MsgLoading)
+		go func() {
+			if err := currentTab.controller.SetLayout(opts); err != nil {
+				currentTab.hideLoading()
+				dialog.ShowError(fmt.Errorf(ui.tr.MsgLayoutFailed, err), ui.window)
+				return
+			}
+
+			// 页数变化后目录页码也随之改变
+			outline, err := currentTab.controller.GetOutline()
+			if err != nil {
+				outline = nil
+			}
+			fyne.Do(currentTab.clearSearch)
+			currentTab.outline.SetItems(outline)
+			currentTab.thumbnails.SetDocument(currentTab.controller.engine)
+			currentTab.renderPage(ui)
+			ui.updateStatusBar()
+		}()
+	}, ui.window)
+}
+
+// renderPage 渲染当前页面（PDFTab 方法）
+func (tab *PDFTab) renderPage(ui *ViewerUI) {
+	if !tab.controller.HasDocument() {
+		return
+	}
+
+	// 自动适配模式下按当前页（不同页尺寸可能不同）和视图区大小计算缩放
+	if tab.fitToViewport(ui) && tab == ui.getCurrentTab() {
+		ui.updateZoomLabel()
+	}
+
+	// 连续模式下由连续视图逐页渲染并绘制高亮，视图只在界面协程中访问
+	if tab.continuous != nil {
+		view, target := tab.continuous, tab.scrollTarget
+		tab.scrollTarget = nil
+		fyne.Do(func() {
+			view.Sync()
+			if target != nil {
+				view.ScrollToRect(target.Page, target.Rect)
+			}
+			view.RefreshOverlays()
+		})
+		tab.outline.SyncToPage(tab.controller.GetCurrentPage())
+		tab.thumbnails.SyncToPage(tab.controller.GetCurrentPage())
+		return
+	}
+
+	page := tab.controller.GetCurrentPage()
+	pages := tab.controller.GetVisiblePages()
+	dpi := tab.controller.GetDPI()
+
+	// 渲染由文档的工作协程串行执行，过期请求的结果会被丢弃
+	tab.controller.RenderCurrentPage(context.Background(), func(img image.Image, err error) {
+		if err != nil {
+			tab.showError(fmt.Sprintf(ui.tr.MsgRenderFailed, err))
+			return
+		}
+
+		// 更新界面
+		tab.imageCanvas.Image = img
+		tab.imageCanvas.Refresh()
+		tab.hideLoading() // 隐藏加载提示
+
+		tab.placements = tab.controller.GetPagePlacements(pages)
+		tab.displayedDPI = dpi
+		bounds := img.Bounds()
+		tab.overlay.SetPage(bounds.Dx(), bounds.Dy(), dpi)
+		tab.updateOverlay()
+		tab.outline.SyncToPage(page)
+		tab.thumbnails.SyncToPage(page)
+
+		// 读取显示页上的链接并换算到图像坐标，失败时按无链接处理
+		tab.links = nil
+		for _, p := range pages {
+			links, err := tab.controller.GetPageLinks(p)
+			if err != nil {
+				continue
+			}
+			for _, link := range links {
+				rect, shown := tab.imageRect(p, link.Rect)
+				if !shown {
+					continue
+				}
+				link.Rect = rect
+				tab.links = append(tab.links, link)
+			}
+		}
+		tab.hoverLink = nil
+		tab.canvasWrapper.SetPointer(false)
+
+		if hit := tab.scrollTarget; hit != nil {
+			if rect, ok := tab.imageRect(hit.Page, hit.Rect); ok {
+				tab.scrollToRect(rect)
+			}
+			tab.scrollTarget = nil
+		}
+	})
+}
+
+// imageRect 将指定页上的矩形换算为单页视图图像上的坐标，该页未显示（或处于连续模式）时返回 false
+func (tab *PDFTab) imageRect(page int, rect PageRect) (PageRect, bool) {
+	if tab.continuous != nil {
+		return rect, false
+	}
+	placement, ok := tab.placements[page]
+	if !ok {
+		return rect, false
+	}
+	return placement.Transform(rect), true
+}
+
+// updateOverlay 刷新当前显示页上的高亮
+func (tab *PDFTab) updateOverlay() {
+	if tab.continuous != nil {
+		tab.continuous.RefreshOverlays()
+		return
+	}
+	tab.overlay.SetRects(append(tab.searchHighlights(tab.imageRect), tab.syncHighlights(tab.imageRect)...))
+}
+
+// pageHighlights 返回连续模式下一页上的搜索和正向搜索高亮（该页图像坐标）
+func (tab *PDFTab) pageHighlights(page int, placement pagePlacement) []overlayRect {
+	imageRect := func(p int, rect PageRect) (PageRect, bool) {
+		if p != page {
+			return rect, false
+		}
+		return placement.Transform(rect), true
+	}
+	return append(tab.searchHighlights(imageRect), tab.syncHighlights(imageRect)...)
+}
+
+// showLoading 显示加载提示（PDFTab 方法）
+func (tab *PDFTab) showLoading(message string) {
+	tab.loadingLabel.SetText(message)
+	tab.loadingLabel.Show()
+}
+
+// hideLoading 隐藏加载提示（PDFTab 方法）
+func (tab *PDFTab) hideLoading() {
+	tab.loadingLabel.Hide()
+}
+
+// showError 显示错误信息（PDFTab 方法）
+func (tab *PDFTab) showError(message string) {
+	tab.loadingLabel.SetText(message)
+}
+
+// SetPrefetch 设置所有标签页（包括之后新建的）的预取页数和是否按翻页方向预取
+func (ui *ViewerUI) SetPrefetch(count int, directional bool) {
+	ui.prefetchCount = count
+	ui.prefetchDirectional = directional
+	for _, tab := range ui.tabs {
+		tab.controller.SetPrefetch(count, directional)
+	}
+}
+
+// Show 显示窗口
+func (ui *ViewerUI) Show() {
+	ui.window.ShowAndRun()
+}
+
+// mustParseURL 解析 URL，如果失败则 panic（用于常量 URL）
+func mustParseURL(urlStr string) *url.URL {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// onShowShortcuts 显示快捷键说明
+func (ui *ViewerUI) onShowShortcuts() {
+	dialog.ShowInformation(ui.tr.DialogShortcutsTitle, ui.tr.DialogShortcutsText, ui.window)
+}
+
+// onShowAbout 显示关于信息
+func (ui *ViewerUI) onShowAbout() {
+	// 创建包含可点击链接的自定义对话框内容
+	var aboutContent fyne.CanvasObject
+
+	if ui.currentLang == LangChinese {
+		// 中文版本
+		aboutContent = container.NewVBox(
+			widget.NewLabel("PDF 阅读器 v1.2.4"),
+			widget.NewLabel(""),
+			widget.NewLabel("基于 Fyne + go-fitz 开发"),
+			widget.NewLabel("简洁、高效的 PDF 阅读工具"),
+			widget.NewLabel(""),
+			container.NewHBox(
+				widget.NewLabel("GitHub:"),
+				widget.NewHyperlink("https://github.com/tools4daily/pdfviewer",
+					mustParseURL("https://github.com/tools4daily/pdfviewer")),
+			),
+			widget.NewLabel(""),
+			widget.NewLabel("开源许可:"),
+			widget.NewLabel("- Fyne: BSD-3-Clause"),
+			widget.NewLabel("- go-fitz: AGPL-3.0"),
+			widget.NewLabel("- pdfcpu: Apache-2.0"),
+		)
+	} else {
+		// 英文版本
+		aboutContent = container.NewVBox(
+			widget.NewLabel("PDF Reader v1.2.4"),
+			widget.NewLabel(""),
+			widget.NewLabel("Built with Fyne + go-fitz"),
+			widget.NewLabel("A simple and efficient PDF reading tool"),
+			widget.NewLabel(""),
+			container.NewHBox(
+				widget.NewLabel("GitHub:"),
+				widget.NewHyperlink("https://github.com/tools4daily/pdfviewer",
+					mustParseURL("https://github.com/tools4daily/pdfviewer")),
+			),
+			widget.NewLabel(""),
+			widget.NewLabel("Open Source Licenses:"),
+			widget.NewLabel("- Fyne: BSD-3-Clause"),
+			widget.NewLabel("- go-fitz: AGPL-3.0"),
+			widget.NewLabel("- pdfcpu: Apache-2.0"),
+		)
+	}
+
+	// 创建自定义对话框
+	d := dialog.NewCustom(ui.tr.DialogAboutTitle, "OK", aboutContent, ui.window)
+	d.Show()
+}
+
+// switchLanguage 切换语言
+func (ui *ViewerUI) switchLanguage(lang Language) {
+	if ui.currentLang == lang {
+		return // 已经是当前语言，无需切换
+	}
+
+	// 更新语言设置
+	ui.currentLang = lang
+	ui.tr = GetTranslations(lang)
+
+	// 更新窗口标题
+	ui.window.SetTitle(ui.tr.WindowTitle)
+
+	// 重建菜单栏
+	ui.window.SetMainMenu(ui.createMenuBar())
+
+	// 更新页码输入框提示
+	ui.pageEntry.SetPlaceHolder(ui.tr.HintPageEntry)
+
+	// 更新搜索栏文本
+	ui.searchEntry.SetPlaceHolder(ui.tr.HintSearch)
+	ui.searchCase.Text = ui.tr.SearchCaseSensitive
+	ui.searchCase.Refresh()
+	ui.searchWord.Text = ui.tr.SearchWholeWord
+	ui.searchWord.Refresh()
+	ui.searchRegex.Text = ui.tr.SearchRegex
+	ui.searchRegex.Refresh()
+
+	// 更新各标签页侧边栏文本
+	for _, tab := range ui.tabs {
+		tab.outline.SetEmptyText(ui.tr.MsgNoOutline)
+	}
+
+	// 更新状态栏
+	ui.updateStatusBar()
+
+	// 更新缩放标签
+	ui.updateZoomLabel()
+
+	// 刷新窗口
+	ui.window.Canvas().Refresh(ui.window.Content())
+}
+
+// scrollableCanvas 支持滚轮翻页的自定义 widget
+type scrollableCanvas struct {
+	widget.BaseWidget
+	content     fyne.CanvasObject
+	onScroll    func(scrolled *fyne.ScrollEvent)
+	onDoubleTap func()
+	onTap       func(ev *fyne.PointEvent)
+	onHover     func(pos fyne.Position)
+	pointer     bool             // 是否显示手形光标（鼠标位于链接上）
+	modifier    fyne.KeyModifier // 最近一次按下鼠标时的修饰键
+}
+
+func newScrollableCanvas(content fyne.CanvasObject, onScroll func(*fyne.ScrollEvent), onDoubleTap func(), onTap func(*fyne.PointEvent), onHover func(fyne.Position)) *scrollableCanvas {
+	sc := &scrollableCanvas{
+		content:     content,
+		onScroll:    onScroll,
+		onDoubleTap: onDoubleTap,
+		onTap:       onTap,
+		onHover:     onHover,
+	}
+	sc.ExtendBaseWidget(sc)
+	return sc
+}
+
+// SetPointer 设置是否显示手形光标
+func (sc *scrollableCanvas) SetPointer(pointer bool) {
+	sc.pointer = pointer
+}
+
+func (sc *scrollableCanvas) Cursor() desktop.Cursor {
+	if sc.pointer {
+		return desktop.PointerCursor
+	}
+	return desktop.DefaultCursor
+}
+
+func (sc *scrollableCanvas) MouseIn(ev *desktop.MouseEvent) {
+	sc.MouseMoved(ev)
+}
+
+func (sc *scrollableCanvas) MouseMoved(ev *desktop.MouseEvent) {
+	if sc.onHover != nil {
+		sc.onHover(ev.Position)
+	}
+}
+
+func (sc *scrollableCanvas) MouseDown(ev *desktop.MouseEvent) {
+	sc.modifier = ev.Modifier
+}
+
+func (sc *scrollableCanvas) MouseUp(ev *desktop.MouseEvent) {}
+
+func (sc *scrollableCanvas) MouseOut() {
+	if sc.onHover != nil {
+		sc.onHover(fyne.NewPos(-1, -1))
+	}
+}
+
+func (sc *scrollableCanvas) CreateRenderer() fyne.WidgetRenderer {
+	return &scrollableCanvasRenderer{
+		canvas:  sc,
+		content: sc.content,
+	}
+}
+
+func (sc *scrollableCanvas) Scrolled(ev *fyne.ScrollEvent) {
+	if sc.onScroll != nil {
+		sc.onScroll(ev)
+	}
+}
+
+func (sc *scrollableCanvas) DoubleTapped(ev *fyne.PointEvent) {
+	if sc.onDoubleTap != nil {
+		sc.onDoubleTap()
+	}
+}
+
+func (sc *scrollableCanvas) Tapped(ev *fyne.PointEvent) {
+	if sc.onTap != nil {
+		sc.onTap(ev)
+	}
+}
+
+type scrollableCanvasRenderer struct {
+	canvas  *scrollableCanvas
+	content fyne.CanvasObject
+}
+
+func (r *scrollableCanvasRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *scrollableCanvasRenderer) MinSize() fyne.Size {
+	return r.content.MinSize()
+}
+
+func (r *scrollableCanvasRenderer) Refresh() {
+	canvas.Refresh(r.content)
+}
+
+func (r *scrollableCanvasRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.content}
+}
+
+func (r *scrollableCanvasRenderer) Destroy() {}
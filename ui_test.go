@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSamePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abs := filepath.Join(wd, "docs", "a.pdf")
+	if !samePath(abs, filepath.Join("docs", "..", "docs", "a.pdf")) {
+		t.Errorf("samePath(%q, relative path) = false, want true", abs)
+	}
+	if samePath(abs, filepath.Join(wd, "docs", "b.pdf")) {
+		t.Error("samePath() of different files = true, want false")
+	}
+	if samePath("", "") {
+		t.Error("samePath(\"\", \"\") = true, want false")
+	}
+}
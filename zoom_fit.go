@@ -0,0 +1,46 @@
+package main
+
+import "time"
+
+// ZoomMode 缩放方式：手动缩放或按视口自动适配
+type ZoomMode int
+
+const (
+	ZoomCustom    ZoomMode = iota // 手动缩放
+	ZoomFitWidth                  // 适合宽度
+	ZoomFitPage                   // 适合页面
+	ZoomFitHeight                 // 适合高度
+)
+
+// 缩放级别范围（相对于 baseDPI），自动适配允许缩得更小以便在小窗口中放下整页
+const (
+	minZoom    = 0.5
+	maxZoom    = 3.0
+	minFitZoom = 0.1
+)
+
+// refitDelay 视图区尺寸停止变化后等待多久再重新适配
+const refitDelay = 150 * time.Millisecond
+
+// fitDPI 计算使页面（单位：点）按指定方式放入视口（单位：像素）的 DPI
+// 视口或页面尺寸无效时返回 false
+func fitDPI(mode ZoomMode, pageWidth, pageHeight, viewWidth, viewHeight float64) (float64, bool) {
+	if pageWidth <= 0 || pageHeight <= 0 {
+		return 0, false
+	}
+
+	byWidth := viewWidth * 72 / pageWidth
+	byHeight := viewHeight * 72 / pageHeight
+	switch mode {
+	case ZoomFitWidth:
+		return byWidth, viewWidth > 0
+	case ZoomFitHeight:
+		return byHeight, viewHeight > 0
+	case ZoomFitPage:
+		if byHeight < byWidth {
+			byWidth = byHeight
+		}
+		return byWidth, viewWidth > 0 && viewHeight > 0
+	}
+	return 0, false
+}